@@ -16,13 +16,23 @@
 package deck
 
 import (
+	"cmp"
+	"context"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/binary"
+	"errors"
 	"fmt"
+	"hash/fnv"
+	"io"
+	"math"
+	"math/bits"
 	mathrand "math/rand"
+	randv2 "math/rand/v2"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
-	"time"
 )
 
 // Suit represents the suit of a playing card.
@@ -78,12 +88,53 @@ const (
 	rankMask = 0x3F
 )
 
-const (
-	// maxCardsPerPlayer is the maximum number of cards per player allowed in Deal.
-	// Set to 52 to handle single-player scenarios with a full deck.
-	maxCardsPerPlayer = 52
+// Errors returned by the drawing, peeking and dealing methods can be
+// classified with errors.Is, so that callers can tell programming mistakes
+// from conditions that depend on the state of the deck. The error messages
+// themselves are unchanged and do not include the text of these values.
+//
+// Example:
+//
+//	hands, err := d.Deal(4, 5)
+//	switch {
+//	case errors.Is(err, deck.ErrInsufficientCards):
+//	    // reshuffle the discards into the deck and try again
+//	case errors.Is(err, deck.ErrInvalidArgument):
+//	    panic(err) // a bug in the caller
+//	}
+var (
+	// ErrInvalidArgument reports that a parameter is invalid regardless of
+	// the contents of the deck, e.g. a negative count or zero players.
+	ErrInvalidArgument = errors.New("invalid argument")
+	// ErrInsufficientCards reports that the deck does not hold enough cards
+	// for an otherwise valid request.
+	ErrInsufficientCards = errors.New("insufficient cards")
+	// ErrJokersPresent reports that the deck holds jokers where a standard
+	// 52-card deck is required, as checked by DealStrict52.
+	ErrJokersPresent = errors.New("jokers present")
 )
 
+// deckError is an error with its own message that matches a kind such as
+// ErrInvalidArgument or ErrInsufficientCards under errors.Is.
+type deckError struct {
+	kind error
+	msg  string
+}
+
+func (e *deckError) Error() string { return e.msg }
+
+func (e *deckError) Unwrap() error { return e.kind }
+
+// invalidArgumentf formats an error that matches ErrInvalidArgument.
+func invalidArgumentf(format string, args ...any) error {
+	return &deckError{kind: ErrInvalidArgument, msg: fmt.Sprintf(format, args...)}
+}
+
+// insufficientCardsf formats an error that matches ErrInsufficientCards.
+func insufficientCardsf(format string, args ...any) error {
+	return &deckError{kind: ErrInsufficientCards, msg: fmt.Sprintf(format, args...)}
+}
+
 // Card represents a single playing card using an efficient 1-byte representation.
 // This compact format is ideal for memory efficiency and network transfer.
 // The upper 2 bits represent the suit (0-3), and the lower 6 bits represent the rank (1-13).
@@ -102,10 +153,31 @@ const (
 type Card uint8
 
 // NewCard creates a new Card from a Rank and Suit.
+// It performs no validation; out-of-range input silently produces an invalid card.
+// Use NewCardChecked when the rank or suit come from untrusted input.
 func NewCard(rank Rank, suit Suit) Card {
 	return Card((suit << suitShift) | (Suit(rank) & rankMask))
 }
 
+// NewCardChecked creates a new Card from a Rank and Suit, validating both.
+// Returns an error if the suit is not Spades through Clubs, or if the rank is
+// not Ace through King. Jokers are accepted only with the suits used by
+// NewRedJoker (Hearts) and NewBlackJoker (Spades).
+func NewCardChecked(rank Rank, suit Suit) (Card, error) {
+	if suit > Clubs {
+		return Card(0), fmt.Errorf("invalid suit: %d", suit)
+	}
+	if rank < Ace || rank > BlackJoker {
+		return Card(0), fmt.Errorf("invalid rank: %d", rank)
+	}
+
+	card := NewCard(rank, suit)
+	if !card.IsValid() {
+		return Card(0), fmt.Errorf("invalid suit %s for %s", suit, card)
+	}
+	return card, nil
+}
+
 // NewRedJoker creates a red joker card (Hearts suit, Rank 14).
 func NewRedJoker() Card {
 	return NewCard(RedJoker, Hearts)
@@ -156,11 +228,355 @@ func (c Card) ShortString() string {
 	return fmt.Sprintf("%s%s", c.Rank(), c.Suit().Symbol())
 }
 
+// ANSI escape sequences used by ColorString.
+const (
+	ansiRed   = "\x1b[31m"
+	ansiReset = "\x1b[0m"
+)
+
+// ColorString returns ShortString wrapped in red ANSI escape sequences for
+// Hearts, Diamonds and the red joker, for logging to a terminal. Spades,
+// Clubs and the black joker keep the terminal's default color.
+// If enabled is false, ColorString returns ShortString unchanged, so callers
+// can turn color off when output is not a terminal or NO_COLOR is set.
+//
+// Example:
+//
+//	color := isTerminal && os.Getenv("NO_COLOR") == ""
+//	fmt.Println(card.ColorString(color))
+func (c Card) ColorString(enabled bool) string {
+	red := c.Rank() == RedJoker || (!c.IsJoker() && (c.Suit() == Hearts || c.Suit() == Diamonds))
+	if !enabled || !red {
+		return c.ShortString()
+	}
+	return ansiRed + c.ShortString() + ansiReset
+}
+
+// AccessibleName returns a speakable name for the card, suitable for screen
+// readers and text-to-speech: "Ace of Spades" for regular cards and
+// "Red Joker" or "Black Joker" for jokers.
+func (c Card) AccessibleName() string {
+	switch c.Rank() {
+	case RedJoker:
+		return "Red Joker"
+	case BlackJoker:
+		return "Black Joker"
+	default:
+		return fmt.Sprintf("%s of %s", c.Rank(), c.Suit())
+	}
+}
+
+// AccessibleNameWithArticle returns AccessibleName preceded by "the",
+// e.g. "the Queen of Hearts", for use within a sentence.
+func (c Card) AccessibleNameWithArticle() string {
+	return "the " + c.AccessibleName()
+}
+
+// Format implements fmt.Formatter, giving control over card rendering in
+// tabular output. The %v and %s verbs print the long form from String, or
+// the short form from ShortString when the '+' flag is set. The %q verb
+// prints the same text quoted. Width and the '-' flag pad the result,
+// counting runes so that suit symbols line up. The %#v verb and any other
+// verb format the card's underlying byte value, so %#v prints Go syntax such
+// as 0x1.
+//
+// Example:
+//
+//	fmt.Printf("[%5v]\n", card)   // "[Ace of Spades]"
+//	fmt.Printf("[%+5v]\n", card)  // "[ Ace♠]"
+//	fmt.Printf("[%-+5v]\n", card) // "[Ace♠ ]"
+func (c Card) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		fmt.Fprintf(f, fmt.FormatString(f, verb), uint8(c))
+	case verb == 'v' || verb == 's' || verb == 'q':
+		s := c.String()
+		if f.Flag('+') {
+			s = c.ShortString()
+		}
+		if verb == 'q' {
+			// Quote here since the '+' flag would otherwise request ASCII-only quoting
+			s = strconv.Quote(s)
+		}
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), s)
+	default:
+		fmt.Fprintf(f, fmt.FormatString(f, verb), uint8(c))
+	}
+}
+
+// ParseCard parses a card from its ShortString representation, such as
+// "Ace♠", "10♦", "Queen♣", "JKR" (red joker) or "JKB" (black joker).
+// Returns an error if s is not a valid short card representation.
+func ParseCard(s string) (Card, error) {
+	switch s {
+	case "JKR":
+		return NewRedJoker(), nil
+	case "JKB":
+		return NewBlackJoker(), nil
+	}
+
+	for suit := Spades; suit <= Clubs; suit++ {
+		rankStr, ok := strings.CutSuffix(s, suit.Symbol())
+		if !ok {
+			continue
+		}
+		for rank := Ace; rank <= King; rank++ {
+			if rank.String() == rankStr {
+				return NewCard(rank, suit), nil
+			}
+		}
+		break
+	}
+	return Card(0), fmt.Errorf("invalid card: %q", s)
+}
+
 // IsJoker returns true if the card is a joker (Rank >= 14).
 func (c Card) IsJoker() bool {
 	return c.Rank() >= RedJoker
 }
 
+// Compare returns -1 if c sorts before other, 1 if it sorts after, and 0 if
+// they are the same card, following the package's default ordering used by
+// Deck.Sort: regular cards by suit (Spades, Hearts, Diamonds, Clubs) and then
+// by rank (Ace through King), followed by all jokers, with the red joker
+// before the black joker.
+//
+// Compare has the signature expected by slices.SortFunc:
+//
+//	slices.SortFunc(hand, deck.Card.Compare)
+func (c Card) Compare(other Card) int {
+	cRank, oRank := c.Rank(), other.Rank()
+	cJoker, oJoker := cRank >= RedJoker, oRank >= RedJoker
+
+	// If one is a joker and the other isn't, non-joker comes first
+	if cJoker != oJoker {
+		if cJoker {
+			return 1
+		}
+		return -1
+	}
+
+	// Regular cards: sort by suit, then rank.
+	// Jokers: sort by rank (Red Joker=14 < Black Joker=15)
+	if !cJoker && c.Suit() != other.Suit() {
+		return cmp.Compare(c.Suit(), other.Suit())
+	}
+	return cmp.Compare(cRank, oRank)
+}
+
+// CompareTrick compares two cards played to the same trick and returns 1 if
+// a beats b, -1 if b beats a, and 0 if neither can win the trick or the cards
+// are equal. A trump beats any
+// non-trump card, a card of leadSuit beats any card that is neither trump nor
+// of leadSuit, and cards of the same winning suit are compared by rank, with
+// Ace above King if aceHigh is true and below Two otherwise.
+// Jokers never win a trick. For no-trump play, pass leadSuit as trumpSuit.
+//
+// Example:
+//
+//	winner := trick[0]
+//	for _, c := range trick[1:] {
+//	    if deck.CompareTrick(c, winner, trick[0].Suit(), deck.Spades, true) > 0 {
+//	        winner = c
+//	    }
+//	}
+func CompareTrick(a, b Card, leadSuit, trumpSuit Suit, aceHigh bool) int {
+	// strength ranks the suits that can win: 2 for trump, 1 for the lead suit
+	strength := func(c Card) int {
+		switch {
+		case c.IsJoker():
+			return 0
+		case c.Suit() == trumpSuit:
+			return 2
+		case c.Suit() == leadSuit:
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	sa, sb := strength(a), strength(b)
+	if sa != sb {
+		return cmp.Compare(sa, sb)
+	}
+	if sa == 0 {
+		return 0
+	}
+	return cmp.Compare(rankValue(a, aceHigh), rankValue(b, aceHigh))
+}
+
+// rankValue returns the rank of c as a number for comparisons, counting Ace
+// as one above King if aceHigh is true.
+func rankValue(c Card, aceHigh bool) int {
+	if aceHigh && c.Rank() == Ace {
+		return int(King) + 1
+	}
+	return int(c.Rank())
+}
+
+// HighestCard returns the card of highest rank in cards, ignoring suit, with
+// Ace above King if aceHigh is true and below Two otherwise. Jokers rank above
+// every other card, the black joker above the red one. If several cards share
+// the highest rank, the first of them is returned.
+// Returns false if cards is empty.
+//
+// Example:
+//
+//	top, ok := deck.HighestCard(hand, true)
+func HighestCard(cards []Card, aceHigh bool) (Card, bool) {
+	return extremeCard(cards, aceHigh, 1)
+}
+
+// LowestCard is like HighestCard but returns the card of lowest rank.
+func LowestCard(cards []Card, aceHigh bool) (Card, bool) {
+	return extremeCard(cards, aceHigh, -1)
+}
+
+// extremeCard returns the first card whose rank compares as dir (1 for
+// highest, -1 for lowest) against every other card.
+func extremeCard(cards []Card, aceHigh bool, dir int) (Card, bool) {
+	if len(cards) == 0 {
+		return Card(0), false
+	}
+
+	value := func(c Card) int {
+		if c.IsJoker() {
+			// Above an Ace-high Ace, keeping red below black
+			return int(c.Rank()) + 2
+		}
+		return rankValue(c, aceHigh)
+	}
+
+	best := cards[0]
+	for _, card := range cards[1:] {
+		if cmp.Compare(value(card), value(best)) == dir {
+			best = card
+		}
+	}
+	return best, true
+}
+
+// RankDistance returns the absolute difference between the ranks of a and b,
+// ignoring suit, with Ace above King if aceHigh is true and below Two
+// otherwise. Consecutive ranks are at distance 1, e.g. King and Ace with
+// aceHigh, or Ace and Two without it, and cards of equal rank at distance 0.
+// Jokers have no place in a sequence, so RankDistance returns -1 if either
+// card is a joker.
+//
+// Example:
+//
+//	if deck.RankDistance(prev, next, true) == 1 {
+//	    // next extends the run
+//	}
+func RankDistance(a, b Card, aceHigh bool) int {
+	if a.IsJoker() || b.IsJoker() {
+		return -1
+	}
+
+	d := rankValue(a, aceHigh) - rankValue(b, aceHigh)
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// Ordinal returns a dense 0-based index for the card, suitable for lookup
+// tables and bitsets. Standard cards map to 0-51 in New order (Spades,
+// Hearts, Diamonds, Clubs, each Ace through King), the red joker to 52 and
+// the black joker to 53. Returns -1 if the card is not valid.
+func (c Card) Ordinal() int {
+	if !c.IsValid() {
+		return -1
+	}
+
+	switch c.Rank() {
+	case RedJoker:
+		return 52
+	case BlackJoker:
+		return 53
+	default:
+		return int(c.Suit())*13 + int(c.Rank()-Ace)
+	}
+}
+
+// CardFromOrdinal returns the card with the given ordinal, the inverse of Card.Ordinal.
+// Returns an error if i is outside the range 0-53.
+func CardFromOrdinal(i int) (Card, error) {
+	switch {
+	case i >= 0 && i < 52:
+		return NewCard(Rank(i%13)+Ace, Suit(i/13)), nil
+	case i == 52:
+		return NewRedJoker(), nil
+	case i == 53:
+		return NewBlackJoker(), nil
+	default:
+		return Card(0), fmt.Errorf("ordinal out of range: %d", i)
+	}
+}
+
+// IsValid returns true if the card encodes a legal playing card: a regular card
+// with a rank from Ace to King, a red joker (Hearts) or a black joker (Spades).
+func (c Card) IsValid() bool {
+	switch rank := c.Rank(); {
+	case rank >= Ace && rank <= King:
+		return true
+	case rank == RedJoker:
+		return c == NewRedJoker()
+	case rank == BlackJoker:
+		return c == NewBlackJoker()
+	default:
+		return false
+	}
+}
+
+// NextInSuit returns the card of the next higher rank in the same suit, with
+// Ace low: Ace is followed by Two and King has no next card.
+// Returns false for a King, a joker or an invalid card.
+// Use NextInSuitAceHigh when Ace ranks above King.
+func (c Card) NextInSuit() (Card, bool) {
+	if c.IsJoker() || !c.IsValid() || c.Rank() == King {
+		return Card(0), false
+	}
+	return NewCard(c.Rank()+1, c.Suit()), true
+}
+
+// PrevInSuit returns the card of the next lower rank in the same suit, with
+// Ace low: Two is preceded by Ace and Ace has no previous card.
+// Returns false for an Ace, a joker or an invalid card.
+// Use PrevInSuitAceHigh when Ace ranks above King.
+func (c Card) PrevInSuit() (Card, bool) {
+	if c.IsJoker() || !c.IsValid() || c.Rank() == Ace {
+		return Card(0), false
+	}
+	return NewCard(c.Rank()-1, c.Suit()), true
+}
+
+// NextInSuitAceHigh is like NextInSuit but with Ace high: King is followed by
+// Ace and Ace has no next card.
+func (c Card) NextInSuitAceHigh() (Card, bool) {
+	switch {
+	case c.IsJoker() || !c.IsValid() || c.Rank() == Ace:
+		return Card(0), false
+	case c.Rank() == King:
+		return NewCard(Ace, c.Suit()), true
+	default:
+		return NewCard(c.Rank()+1, c.Suit()), true
+	}
+}
+
+// PrevInSuitAceHigh is like PrevInSuit but with Ace high: Ace is preceded by
+// King and Two has no previous card.
+func (c Card) PrevInSuitAceHigh() (Card, bool) {
+	switch {
+	case c.IsJoker() || !c.IsValid() || c.Rank() == Two:
+		return Card(0), false
+	case c.Rank() == Ace:
+		return NewCard(King, c.Suit()), true
+	default:
+		return NewCard(c.Rank()-1, c.Suit()), true
+	}
+}
+
 // Shuffler is an interface for custom random number generators.
 // Implement this interface to provide deterministic or custom shuffling behavior.
 type Shuffler interface {
@@ -171,35 +587,79 @@ type Shuffler interface {
 	Shuffle(n int, swap func(i, j int))
 }
 
+// ContextShuffler is a Shuffler that can be cancelled, such as one that
+// fetches entropy from a remote randomness service. Deck.ShuffleContext uses
+// ShuffleContext when a shuffler implements it.
+type ContextShuffler interface {
+	Shuffler
+	// ShuffleContext is like Shuffle but stops and returns a non-nil error,
+	// typically ctx.Err(), when ctx is done before the shuffle completes.
+	ShuffleContext(ctx context.Context, n int, swap func(i, j int)) error
+}
+
 // SecureShuffler uses crypto/rand for cryptographically secure shuffling.
 // This is suitable for applications requiring unpredictable randomness,
 // such as online card games or gambling applications.
 type SecureShuffler struct{}
 
 // Shuffle implements the Shuffler interface using crypto/rand.
+// Random bytes are read in batches, so a 52-card shuffle needs a single read.
 func (s SecureShuffler) Shuffle(n int, swap func(i, j int)) {
+	var r secureRand
 	for i := n - 1; i > 0; i-- {
-		// Choose a random number j in the range [0, i] with 0 allocations
-		// which is more efficient than the [rand.Int] implementation.
-		var b [8]byte
-		_, _ = rand.Read(b[:]) // [rand.Read] never returns an error https://pkg.go.dev/crypto/rand#Read
-		// modulo bias percentage is negligible: 1 / (2^64 / 52) ≈ 0.00000000028%
-		j := int(binary.LittleEndian.Uint64(b[:]) % uint64(i+1))
+		j := int(r.uint64n(uint64(i + 1)))
 		swap(i, j)
 	}
 }
 
-// DefaultShuffler uses math/rand with time-based seeding.
+// secureRandBufferSize is the number of random bytes secureRand reads at once,
+// enough for the 51 draws of a 52-card shuffle.
+const secureRandBufferSize = 512
+
+// secureRand hands out random numbers from a buffer of crypto/rand bytes,
+// refilling it when it runs out. The zero value is ready to use.
+type secureRand struct {
+	buf   [secureRandBufferSize]byte
+	avail int // number of unused bytes at the end of buf
+}
+
+// next returns the next 64 random bits.
+func (r *secureRand) next() uint64 {
+	if r.avail == 0 {
+		_, _ = rand.Read(r.buf[:]) // [rand.Read] never returns an error https://pkg.go.dev/crypto/rand#Read
+		r.avail = len(r.buf)
+	}
+	v := binary.LittleEndian.Uint64(r.buf[len(r.buf)-r.avail:])
+	r.avail -= 8
+	return v
+}
+
+// uint64n returns a uniformly distributed number in [0, n) using Lemire's
+// multiply-and-reject method, which avoids the modulo bias of r % n.
+func (r *secureRand) uint64n(n uint64) uint64 {
+	hi, lo := bits.Mul64(r.next(), n)
+	if lo < n {
+		threshold := -n % n
+		for lo < threshold {
+			hi, lo = bits.Mul64(r.next(), n)
+		}
+	}
+	return hi
+}
+
+// DefaultShuffler uses math/rand with a seed drawn from crypto/rand.
 // This is not secure enough and is only suitable for trivial applications.
 type DefaultShuffler struct {
 	rng *mathrand.Rand
 }
 
-// NewDefaultShuffler creates a new DefaultShuffler with time-based seed.
+// NewDefaultShuffler creates a new DefaultShuffler with a random seed read
+// from crypto/rand, so shufflers created in quick succession, even within the
+// same clock tick, produce independent orders.
 func NewDefaultShuffler() *DefaultShuffler {
-	return &DefaultShuffler{
-		rng: mathrand.New(mathrand.NewSource(time.Now().UnixNano())),
-	}
+	var b [8]byte
+	_, _ = rand.Read(b[:]) // [rand.Read] never returns an error https://pkg.go.dev/crypto/rand#Read
+	return NewSeededShuffler(int64(binary.LittleEndian.Uint64(b[:])))
 }
 
 // NewSeededShuffler creates a new DefaultShuffler with a specific seed.
@@ -215,6 +675,347 @@ func (s *DefaultShuffler) Shuffle(n int, swap func(i, j int)) {
 	s.rng.Shuffle(n, swap)
 }
 
+// NewSourceShuffler returns a Shuffler that performs an unbiased Fisher-Yates
+// shuffle driven by src. This lets existing RNG infrastructure (seeded,
+// testable or hardware-backed sources) be reused without implementing the
+// Shuffler interface. The source is used directly, so the returned Shuffler
+// is safe for concurrent use only if src is.
+func NewSourceShuffler(src mathrand.Source64) Shuffler {
+	return sourceShuffler{src: src}
+}
+
+// sourceShuffler adapts a source of uniformly distributed 64-bit values, such
+// as a math/rand Source64 or a math/rand/v2 ChaCha8, to the Shuffler interface.
+// Its algorithm is the one documented for StableShuffler and must not change,
+// since ShuffleWithBytes promises the same order on every Go version.
+type sourceShuffler struct {
+	src interface{ Uint64() uint64 }
+}
+
+// Shuffle implements the Shuffler interface using the wrapped source.
+func (s sourceShuffler) Shuffle(n int, swap func(i, j int)) {
+	for i := n - 1; i > 0; i-- {
+		j := int(s.uint64n(uint64(i + 1)))
+		swap(i, j)
+	}
+}
+
+// uint64n returns a uniformly distributed number in [0, n) using Lemire's
+// multiply-and-reject method, which avoids the modulo bias of r % n.
+func (s sourceShuffler) uint64n(n uint64) uint64 {
+	hi, lo := bits.Mul64(s.src.Uint64(), n)
+	if lo < n {
+		threshold := -n % n
+		for lo < threshold {
+			hi, lo = bits.Mul64(s.src.Uint64(), n)
+		}
+	}
+	return hi
+}
+
+// ChaCha8Shuffler uses the ChaCha8 CSPRNG from math/rand/v2 seeded with a
+// 32-byte seed. Identical seeds produce identical shuffles, while the output
+// remains of cryptographic quality. This makes it suitable for auditable,
+// provably-fair games where the seed is revealed after play.
+type ChaCha8Shuffler struct {
+	rng *randv2.Rand
+}
+
+// NewChaCha8Shuffler creates a new ChaCha8Shuffler with the given seed.
+// The seed must be kept secret until the shuffle is meant to be verifiable.
+func NewChaCha8Shuffler(seed [32]byte) *ChaCha8Shuffler {
+	return &ChaCha8Shuffler{
+		rng: randv2.New(randv2.NewChaCha8(seed)),
+	}
+}
+
+// Shuffle implements the Shuffler interface using ChaCha8.
+func (s *ChaCha8Shuffler) Shuffle(n int, swap func(i, j int)) {
+	s.rng.Shuffle(n, swap)
+}
+
+// StableShuffler is a deterministic shuffler whose output is fully specified
+// by this package rather than by math/rand, so a given seed yields the same
+// permutation on every platform and Go version. Use it for long-lived replays
+// and for client/server agreement. It is not suitable for fair games because
+// its output is predictable from the seed.
+//
+// The algorithm is fixed and will not change:
+//
+//  1. The generator is splitmix64 with a 64-bit state initialised to the seed.
+//     Each call adds 0x9E3779B97F4A7C15 to the state (wrapping), then mixes
+//     z := state with z = (z ^ z>>30) * 0xBF58476D1CE4E5B9,
+//     z = (z ^ z>>27) * 0x94D049BB133111EB and returns z ^ z>>31.
+//  2. A bounded value in [0, m) is taken from the high 64 bits of the 128-bit
+//     product of the next generator output and m. If the low 64 bits are less
+//     than (2^64 - m) mod m the output is discarded and a new one is drawn,
+//     which removes modulo bias.
+//  3. The shuffle is Fisher-Yates from the end: for i from n-1 down to 1,
+//     j is a bounded value in [0, i+1) and elements i and j are swapped.
+type StableShuffler struct {
+	state uint64
+}
+
+// NewStableShuffler creates a new StableShuffler with the given seed.
+// Successive shuffles continue the same generator stream.
+func NewStableShuffler(seed uint64) *StableShuffler {
+	return &StableShuffler{state: seed}
+}
+
+// Shuffle implements the Shuffler interface using splitmix64.
+func (s *StableShuffler) Shuffle(n int, swap func(i, j int)) {
+	for i := n - 1; i > 0; i-- {
+		j := int(s.uint64n(uint64(i + 1)))
+		swap(i, j)
+	}
+}
+
+// next returns the next splitmix64 output.
+func (s *StableShuffler) next() uint64 {
+	s.state += 0x9E3779B97F4A7C15
+	z := s.state
+	z = (z ^ z>>30) * 0xBF58476D1CE4E5B9
+	z = (z ^ z>>27) * 0x94D049BB133111EB
+	return z ^ z>>31
+}
+
+// uint64n returns a uniformly distributed number in [0, n).
+func (s *StableShuffler) uint64n(n uint64) uint64 {
+	hi, lo := bits.Mul64(s.next(), n)
+	if lo < n {
+		threshold := -n % n
+		for lo < threshold {
+			hi, lo = bits.Mul64(s.next(), n)
+		}
+	}
+	return hi
+}
+
+// WeightedShuffler produces deliberately non-uniform, reproducible shuffles
+// for simulating imperfectly shuffled decks, such as studying how clumping
+// affects card counting. It must not be used for fair games.
+//
+// The bias is applied as a Plackett-Luce model: the weight function is called
+// once per element with its index before shuffling, and the shuffled order is
+// built from the top by repeatedly picking one of the remaining elements with
+// probability proportional to its weight. Elements with larger weights tend
+// to end up nearer the top, and equal weights give a uniform shuffle.
+// Elements with a non-positive (or NaN) weight are placed below all positively
+// weighted elements, in uniformly random order.
+//
+// When used with Deck.ShuffleWith, index i refers to the card at position i
+// of the deck at the time of the shuffle.
+type WeightedShuffler struct {
+	rng    *mathrand.Rand
+	weight func(i int) float64
+}
+
+// NewWeightedShuffler creates a new WeightedShuffler with a specific seed and
+// weight function. Identical seeds and weights produce identical shuffles.
+//
+// Example:
+//
+//	cards := d.Cards()
+//	// Ten-value cards are three times as likely to be picked next
+//	shuffler := deck.NewWeightedShuffler(42, func(i int) float64 {
+//	    if cards[i].Rank() >= deck.Ten {
+//	        return 3
+//	    }
+//	    return 1
+//	})
+//	d.ShuffleWith(shuffler)
+func NewWeightedShuffler(seed int64, weight func(i int) float64) *WeightedShuffler {
+	return &WeightedShuffler{
+		rng:    mathrand.New(mathrand.NewSource(seed)),
+		weight: weight,
+	}
+}
+
+// Shuffle implements the Shuffler interface using weighted sampling.
+func (s *WeightedShuffler) Shuffle(n int, swap func(i, j int)) {
+	// Efraimidis-Spirakis keys: sorting by log(u)/w in descending order draws
+	// a permutation from the Plackett-Luce model in a single pass.
+	type entry struct {
+		index    int
+		weighted bool
+		key      float64
+	}
+	entries := make([]entry, n)
+	for i := range entries {
+		w := s.weight(i)
+		u := 1 - s.rng.Float64() // in (0, 1], so log(u) is finite
+		if w > 0 {
+			entries[i] = entry{index: i, weighted: true, key: math.Log(u) / w}
+		} else {
+			entries[i] = entry{index: i, key: u}
+		}
+	}
+	sort.Slice(entries, func(a, b int) bool {
+		if entries[a].weighted != entries[b].weighted {
+			return entries[a].weighted
+		}
+		return entries[a].key > entries[b].key
+	})
+
+	perm := make([]int, n)
+	for p, e := range entries {
+		perm[p] = e.index
+	}
+	permuteWithSwaps(perm, swap)
+}
+
+// permuteWithSwaps calls swap so that position p ends up holding the element
+// originally at perm[p], the convention of Deck.ShuffleTracked.
+func permuteWithSwaps(perm []int, swap func(i, j int)) {
+	// Track where each element currently is
+	at := make([]int, len(perm))  // at[p] is the original index of the element at position p
+	pos := make([]int, len(perm)) // pos[i] is the current position of original element i
+	for i := range at {
+		at[i], pos[i] = i, i
+	}
+	for p, old := range perm {
+		q := pos[old]
+		if q == p {
+			continue
+		}
+		swap(p, q)
+		at[p], at[q] = at[q], at[p]
+		pos[at[p]], pos[at[q]] = p, q
+	}
+}
+
+// ScriptedShuffler replays a fixed script of permutations before handing
+// over to another Shuffler, so that the first few deals of a tutorial or demo
+// are predictable and later ones are random. Each call to Shuffle consumes
+// the next permutation of the script, using the convention of
+// Deck.ShuffleTracked: the element at position i afterwards is the one
+// previously at perm[i]. Once the script is exhausted, Shuffle delegates to
+// the fallback shuffler.
+//
+// A ScriptedShuffler is not safe for concurrent use.
+type ScriptedShuffler struct {
+	script   [][]int
+	fallback Shuffler
+}
+
+// NewScriptedShuffler creates a ScriptedShuffler that plays the permutations
+// of script in order and then uses fallback. Use PermutationTo to build a
+// permutation that arranges a known deck into a chosen order.
+// Returns an error if any entry of script is not a permutation.
+//
+// Example:
+//
+//	d := deck.New()
+//	perm, _ := deck.PermutationTo(d.Cards(), tutorialOrder)
+//	s, err := deck.NewScriptedShuffler(deck.SecureShuffler{}, perm)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	d.ShuffleWith(s) // d now holds tutorialOrder; later shuffles are random
+func NewScriptedShuffler(fallback Shuffler, script ...[]int) (*ScriptedShuffler, error) {
+	cloned := make([][]int, len(script))
+	for i, perm := range script {
+		if err := checkPermutation(perm); err != nil {
+			return nil, fmt.Errorf("script entry %d: %w", i, err)
+		}
+		cloned[i] = slices.Clone(perm)
+	}
+	return &ScriptedShuffler{script: cloned, fallback: fallback}, nil
+}
+
+// Remaining returns the number of scripted permutations not yet played.
+func (s *ScriptedShuffler) Remaining() int {
+	return len(s.script)
+}
+
+// Shuffle implements the Shuffler interface by applying the next scripted
+// permutation, or by calling the fallback shuffler once the script is done.
+// It panics if the next permutation does not have length n.
+func (s *ScriptedShuffler) Shuffle(n int, swap func(i, j int)) {
+	if len(s.script) == 0 {
+		s.fallback.Shuffle(n, swap)
+		return
+	}
+
+	perm := s.script[0]
+	if len(perm) != n {
+		panic(fmt.Sprintf("scripted permutation has length %d, want %d", len(perm), n))
+	}
+	s.script = s.script[1:]
+	permuteWithSwaps(perm, swap)
+}
+
+// PermutationTo returns the permutation that rearranges from into to, in
+// the convention of Deck.ShuffleTracked and Deck.ApplyPermutation: to[i] is
+// from[perm[i]]. Repeated cards are matched in order.
+// Returns an error if to is not a rearrangement of the cards in from.
+func PermutationTo(from, to []Card) ([]int, error) {
+	if len(from) != len(to) {
+		return nil, fmt.Errorf("cannot rearrange %d cards into %d cards", len(from), len(to))
+	}
+
+	// positions[c] lists the unused indices of card c in from, in order
+	var positions [256][]int
+	for i, card := range from {
+		positions[card] = append(positions[card], i)
+	}
+
+	perm := make([]int, len(to))
+	for i, card := range to {
+		if len(positions[card]) == 0 {
+			return nil, fmt.Errorf("card %v at index %d is not available in the source order", card, i)
+		}
+		perm[i] = positions[card][0]
+		positions[card] = positions[card][1:]
+	}
+	return perm, nil
+}
+
+// ShuffleUniformityTest measures how uniformly s shuffles a deck of deckSize
+// cards. It runs trials shuffles of the positions 0 to deckSize-1, counts how
+// often each card lands in each position and returns the chi-square statistic
+// of those counts divided by its (deckSize-1)² degrees of freedom.
+//
+// For a uniform shuffler the result is close to 1, while a biased shuffler,
+// such as the naive "swap every card with any position" shuffle, gives values
+// that keep growing with trials. For a reliable result, trials should be at
+// least 5*deckSize so that every position is expected to see each card five
+// times. Returns NaN if deckSize is less than 2 or trials is less than 1.
+//
+// Example:
+//
+//	if stat := deck.ShuffleUniformityTest(myShuffler, 52, 100000); stat > 1.5 {
+//	    log.Printf("shuffler looks biased: %.2f", stat)
+//	}
+func ShuffleUniformityTest(s Shuffler, deckSize, trials int) float64 {
+	if deckSize < 2 || trials < 1 {
+		return math.NaN()
+	}
+
+	// counts[card*deckSize+pos] is how often card ended up at pos
+	counts := make([]int, deckSize*deckSize)
+	perm := make([]int, deckSize)
+	for t := 0; t < trials; t++ {
+		for i := range perm {
+			perm[i] = i
+		}
+		s.Shuffle(deckSize, func(i, j int) {
+			perm[i], perm[j] = perm[j], perm[i]
+		})
+		for pos, card := range perm {
+			counts[card*deckSize+pos]++
+		}
+	}
+
+	expected := float64(trials) / float64(deckSize)
+	var chiSquare float64
+	for _, observed := range counts {
+		diff := float64(observed) - expected
+		chiSquare += diff * diff / expected
+	}
+	return chiSquare / float64((deckSize-1)*(deckSize-1))
+}
+
 // Deck represents a deck of playing cards.
 // It uses a slice for efficient operations like shuffling and drawing.
 type Deck struct {
@@ -225,13 +1026,26 @@ type Deck struct {
 // The deck is created in sorted order (Spades, Hearts, Diamonds, Clubs,
 // each with Ace through King).
 func New() *Deck {
+	return &Deck{cards: AllCards()}
+}
+
+// AllCards returns a new slice of the 52 standard cards in canonical order:
+// Spades, Hearts, Diamonds, Clubs, each Ace through King.
+// It is useful for table-driven tests and for building lookup tables.
+func AllCards() []Card {
 	cards := make([]Card, 0, 52)
 	for suit := Spades; suit <= Clubs; suit++ {
 		for rank := Ace; rank <= King; rank++ {
 			cards = append(cards, NewCard(rank, suit))
 		}
 	}
-	return &Deck{cards: cards}
+	return cards
+}
+
+// AllCardsWithJokers returns a new slice of the 52 standard cards in
+// canonical order followed by the red joker and the black joker.
+func AllCardsWithJokers() []Card {
+	return append(AllCards(), NewRedJoker(), NewBlackJoker())
 }
 
 // NewMultiple creates a deck with multiple standard 52-card decks.
@@ -255,16 +1069,7 @@ func NewMultiple(count int) (*Deck, error) {
 // NewWithJokers creates a standard 54-card deck (52 regular cards + 2 jokers).
 // The jokers are added at the end: one red joker (Hearts) and one black joker (Spades).
 func NewWithJokers() *Deck {
-	cards := make([]Card, 0, 54)
-	// Add all 52 regular cards
-	for suit := Spades; suit <= Clubs; suit++ {
-		for rank := Ace; rank <= King; rank++ {
-			cards = append(cards, NewCard(rank, suit))
-		}
-	}
-	// Add jokers
-	cards = append(cards, NewRedJoker(), NewBlackJoker())
-	return &Deck{cards: cards}
+	return &Deck{cards: AllCardsWithJokers()}
 }
 
 // NewMultipleWithJokers creates a deck with multiple 54-card decks (including jokers).
@@ -288,8 +1093,35 @@ func NewMultipleWithJokers(count int) (*Deck, error) {
 	return &Deck{cards: cards}, nil
 }
 
-// Len returns the number of cards currently in the deck.
-func (d *Deck) Len() int {
+// NewFromCards creates a deck holding a copy of cards, with cards[0] on top.
+// It is the counterpart of Cards, so NewFromCards(d.Cards()) recreates d.
+// The cards are not validated; use Validate if they come from an untrusted source.
+func NewFromCards(cards []Card) *Deck {
+	return &Deck{cards: slices.Clone(cards)}
+}
+
+// Concat returns a new deck holding the cards of all decks in order: the
+// cards of decks[0] from top to bottom, followed by those of decks[1], and so
+// on. The input decks are left unchanged and nil decks are treated as empty.
+func Concat(decks ...*Deck) *Deck {
+	total := 0
+	for _, d := range decks {
+		if d != nil {
+			total += len(d.cards)
+		}
+	}
+
+	cards := make([]Card, 0, total)
+	for _, d := range decks {
+		if d != nil {
+			cards = append(cards, d.cards...)
+		}
+	}
+	return &Deck{cards: cards}
+}
+
+// Len returns the number of cards currently in the deck.
+func (d *Deck) Len() int {
 	return len(d.cards)
 }
 
@@ -298,8 +1130,14 @@ func (d *Deck) IsEmpty() bool {
 	return len(d.cards) == 0
 }
 
+// HasJokers reports whether the deck holds at least one joker.
+func (d *Deck) HasJokers() bool {
+	return slices.ContainsFunc(d.cards, Card.IsJoker)
+}
+
 // Shuffle randomizes the order of cards in the deck using math/rand.
-// It uses the current time as a seed for the random number generator.
+// Each call seeds the random number generator from crypto/rand, so decks
+// shuffled in a tight loop get independent orders.
 // For cryptographically secure shuffling, use SecureShuffle instead.
 func (d *Deck) Shuffle() {
 	shuffler := NewDefaultShuffler()
@@ -320,6 +1158,56 @@ func (d *Deck) ShuffleWithSeed(seed int64) {
 	d.ShuffleWith(shuffler)
 }
 
+// ShuffleWithBytes randomizes the order of cards deterministically from a
+// seed of any length, such as concatenated client and server nonces. The seed
+// is hashed with SHA-256 into the key of a ChaCha8 generator, so all of its
+// entropy is used. The result is the same as ShuffleCommit, so it can be
+// checked with VerifyShuffle once the seed is revealed.
+//
+// The result is version-stable, so a revealed seed verifies with any build.
+// Unlike ChaCha8Shuffler, which relies on math/rand/v2's Rand.Shuffle, the
+// algorithm is fixed by this package and will not change:
+//
+//  1. The key is the SHA-256 digest of the string
+//     "deck: commit-reveal shuffle key\x00" followed by seed.
+//  2. The generator is math/rand/v2's ChaCha8 with that key, whose output
+//     stream is specified by the C2SP chacha8rand standard.
+//  3. Bounded values and the Fisher-Yates shuffle follow steps 2 and 3 of
+//     StableShuffler, drawing from the ChaCha8 Uint64 outputs instead.
+func (d *Deck) ShuffleWithBytes(seed []byte) {
+	d.ShuffleWith(sourceShuffler{src: randv2.NewChaCha8(commitShuffleKey(seed))})
+}
+
+// ShuffleWithString randomizes the order of cards deterministically from a
+// human-readable seed, such as the code of a daily puzzle, so that a shared
+// code reproduces the same deal.
+//
+// The result is version-stable: the seed is hashed with SHA-256, the first 8
+// bytes of the digest are read as a big-endian integer, and that integer
+// seeds a StableShuffler. The same seed and starting order give the same
+// order on every platform and with every future release of this package.
+// Distinct seeds give distinct orders with overwhelming probability.
+// The order is predictable from the seed, so it must not be used for games
+// played for stakes.
+//
+// Example:
+//
+//	d := deck.New()
+//	d.ShuffleWithString("daily-2026-10-15")
+func (d *Deck) ShuffleWithString(seed string) {
+	d.ShuffleWith(NewStableShuffler(stringShuffleSeed(seed)))
+}
+
+// stringShuffleDomain separates the hash used by ShuffleWithString from
+// other uses of SHA-256 in this package.
+const stringShuffleDomain = "deck: string shuffle seed\x00"
+
+// stringShuffleSeed derives the StableShuffler seed used by ShuffleWithString.
+func stringShuffleSeed(seed string) uint64 {
+	sum := sha256.Sum256([]byte(stringShuffleDomain + seed))
+	return binary.BigEndian.Uint64(sum[:8])
+}
+
 // ShuffleWith randomizes the order of cards using a custom Shuffler.
 // This allows clients to provide their own random number generation strategy.
 func (d *Deck) ShuffleWith(shuffler Shuffler) {
@@ -328,11 +1216,210 @@ func (d *Deck) ShuffleWith(shuffler Shuffler) {
 	})
 }
 
+// ShuffleContext randomizes the order of cards using shuffler, honouring the
+// cancellation of ctx. If shuffler implements ContextShuffler, ctx is passed
+// to its ShuffleContext method; otherwise ctx is only checked before the
+// shuffle starts. If ctx is done or the shuffle fails, the deck remains
+// unchanged and the error is returned.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+//	defer cancel()
+//	if err := d.ShuffleContext(ctx, remoteShuffler); err != nil {
+//	    log.Fatal(err)
+//	}
+func (d *Deck) ShuffleContext(ctx context.Context, shuffler Shuffler) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// Shuffle a copy so that a cancelled shuffle leaves the deck unchanged
+	cards := slices.Clone(d.cards)
+	swap := func(i, j int) {
+		cards[i], cards[j] = cards[j], cards[i]
+	}
+	if cs, ok := shuffler.(ContextShuffler); ok {
+		if err := cs.ShuffleContext(ctx, len(cards), swap); err != nil {
+			return err
+		}
+	} else {
+		shuffler.Shuffle(len(cards), swap)
+	}
+
+	d.cards = cards
+	return nil
+}
+
+// ShuffleRange randomizes the order of the cards at positions [start, end)
+// using a custom Shuffler. Position 0 is the top of the deck. Cards outside
+// the range keep their exact positions, so it can model shuffling part of a
+// deck, such as the top cards after a search.
+// Returns an error and leaves the deck unchanged if the range is invalid.
+func (d *Deck) ShuffleRange(start, end int, shuffler Shuffler) error {
+	if start < 0 || end > len(d.cards) || start > end {
+		return invalidArgumentf("invalid range [%d, %d) for deck of %d cards", start, end, len(d.cards))
+	}
+
+	window := d.cards[start:end]
+	shuffler.Shuffle(len(window), func(i, j int) {
+		window[i], window[j] = window[j], window[i]
+	})
+	return nil
+}
+
+// ShuffleTracked randomizes the order of cards using a custom Shuffler and
+// returns the permutation it applied, mapping each new index to the old index
+// of the card now at that position: after the call, card i was at perm[i].
+// Recording the permutation allows a shuffle to be logged, replayed or
+// reversed, and a shuffler's output distribution to be tested directly.
+func (d *Deck) ShuffleTracked(shuffler Shuffler) []int {
+	perm := make([]int, len(d.cards))
+	for i := range perm {
+		perm[i] = i
+	}
+
+	shuffler.Shuffle(len(d.cards), func(i, j int) {
+		d.cards[i], d.cards[j] = d.cards[j], d.cards[i]
+		perm[i], perm[j] = perm[j], perm[i]
+	})
+	return perm
+}
+
+// ApplyPermutation reorders the deck so that card i is the card previously
+// at perm[i], using the same convention as ShuffleTracked. Applying a
+// recorded permutation to a deck in the same starting order (e.g. a fresh
+// New deck) reproduces the exact shuffle.
+// Returns an error if perm is not a permutation of [0, Len()), leaving the deck unchanged.
+//
+// Example:
+//
+//	perm := d.ShuffleTracked(deck.SecureShuffler{}) // log perm
+//	replay := deck.New()
+//	_ = replay.ApplyPermutation(perm) // replay now matches d
+func (d *Deck) ApplyPermutation(perm []int) error {
+	if err := d.validatePermutation(perm); err != nil {
+		return err
+	}
+
+	cards := make([]Card, len(d.cards))
+	for i, old := range perm {
+		cards[i] = d.cards[old]
+	}
+	copy(d.cards, cards)
+	return nil
+}
+
+// ApplyInversePermutation undoes ApplyPermutation or ShuffleTracked with the
+// same perm, moving the card at position i back to position perm[i].
+// Returns an error if perm is not a permutation of [0, Len()), leaving the deck unchanged.
+func (d *Deck) ApplyInversePermutation(perm []int) error {
+	if err := d.validatePermutation(perm); err != nil {
+		return err
+	}
+
+	cards := make([]Card, len(d.cards))
+	for i, old := range perm {
+		cards[old] = d.cards[i]
+	}
+	copy(d.cards, cards)
+	return nil
+}
+
+// validatePermutation checks that perm contains each index of the deck exactly once.
+func (d *Deck) validatePermutation(perm []int) error {
+	if len(perm) != len(d.cards) {
+		return fmt.Errorf("invalid permutation: length %d, want %d", len(perm), len(d.cards))
+	}
+	return checkPermutation(perm)
+}
+
+// checkPermutation checks that perm contains each index of [0, len(perm)) exactly once.
+func checkPermutation(perm []int) error {
+	seen := make([]bool, len(perm))
+	for i, p := range perm {
+		if p < 0 || p >= len(perm) {
+			return fmt.Errorf("invalid permutation: index %d out of range at position %d", p, i)
+		}
+		if seen[p] {
+			return fmt.Errorf("invalid permutation: duplicate index %d at position %d", p, i)
+		}
+		seen[p] = true
+	}
+	return nil
+}
+
+// commitShuffleDomain separates the shuffle key derivation from the seed commitment,
+// so that publishing the commitment reveals nothing about the shuffle.
+const commitShuffleDomain = "deck: commit-reveal shuffle key\x00"
+
+// ShuffleCommit shuffles the deck deterministically from seed and returns
+// the SHA-256 commitment of the seed.
+//
+// This supports the commit-reveal scheme used by provably-fair card rooms:
+// publish the commitment before the game, reveal the seed after it, and let
+// players check it with VerifyCommitment and VerifyShuffle. The seed should
+// contain at least 32 bytes of secret randomness.
+//
+// The shuffle is the one performed by ShuffleWithBytes, whose algorithm is
+// fixed by this package and does not depend on math/rand, so a revealed seed
+// verifies with VerifyShuffle on every platform and Go version.
+//
+// Example:
+//
+//	seed := make([]byte, 32)
+//	rand.Read(seed)
+//	original := d.Cards()
+//	commitment := deck.ShuffleCommit(d, seed) // publish commitment
+//	// ... play the game, then reveal seed ...
+//	ok := deck.VerifyCommitment(commitment, seed) && deck.VerifyShuffle(original, seed, d)
+func ShuffleCommit(d *Deck, seed []byte) (commitment [32]byte) {
+	d.ShuffleWithBytes(seed)
+	return sha256.Sum256(seed)
+}
+
+// VerifyCommitment reports whether commitment is the SHA-256 commitment of seed,
+// as returned by ShuffleCommit.
+func VerifyCommitment(commitment [32]byte, seed []byte) bool {
+	return sha256.Sum256(seed) == commitment
+}
+
+// VerifyShuffle reports whether shuffling originalOrder with seed, as done by
+// ShuffleCommit, produces exactly the cards of result in the same order.
+// It replays the version-stable algorithm documented on ShuffleWithBytes.
+func VerifyShuffle(originalOrder []Card, seed []byte, result *Deck) bool {
+	if len(originalOrder) != len(result.cards) {
+		return false
+	}
+
+	replay := &Deck{cards: make([]Card, len(originalOrder))}
+	copy(replay.cards, originalOrder)
+	replay.ShuffleWithBytes(seed)
+
+	for i, card := range replay.cards {
+		if card != result.cards[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// commitShuffleKey derives the ChaCha8 key used by ShuffleWithBytes from seed.
+func commitShuffleKey(seed []byte) [32]byte {
+	h := sha256.New()
+	_, _ = h.Write([]byte(commitShuffleDomain)) // [hash.Hash.Write] never returns an error
+	_, _ = h.Write(seed)
+
+	var key [32]byte
+	h.Sum(key[:0])
+	return key
+}
+
 // Draw removes and returns the top card from the deck.
 // Returns an error if the deck is empty.
 func (d *Deck) Draw() (Card, error) {
 	if d.IsEmpty() {
-		return Card(0), fmt.Errorf("cannot draw from empty deck")
+		return Card(0), insufficientCardsf("cannot draw from empty deck")
 	}
 
 	card := d.cards[0]
@@ -340,6 +1427,47 @@ func (d *Deck) Draw() (Card, error) {
 	return card, nil
 }
 
+// DrawOrReshuffle draws the top card like Draw, but when the deck is empty it
+// first moves every card of discard into the deck, shuffles them with s and
+// leaves discard empty, as when the stock runs out in Crazy Eights. A game
+// that keeps the top discard face up should remove it before calling.
+// A nil discard is treated as an empty discard pile.
+// Returns an error if both the deck and discard are empty. If s panics, both
+// piles remain unchanged.
+//
+// Example:
+//
+//	card, err := stock.DrawOrReshuffle(discards, deck.SecureShuffler{})
+//	if err != nil {
+//	    // every card is in a player's hand
+//	}
+func (d *Deck) DrawOrReshuffle(discard *Deck, s Shuffler) (Card, error) {
+	if d.IsEmpty() {
+		if discard == nil || discard.IsEmpty() {
+			return Card(0), insufficientCardsf("cannot draw: deck and discard pile are empty")
+		}
+
+		cards := slices.Clone(discard.cards)
+		s.Shuffle(len(cards), func(i, j int) {
+			cards[i], cards[j] = cards[j], cards[i]
+		})
+		d.cards = cards
+		discard.Clear()
+	}
+
+	return d.Draw()
+}
+
+// DrawContext is like Draw but first checks ctx, returning its error without
+// drawing if ctx is already done. It lets draws share the cancellation of a
+// request that also shuffles with ShuffleContext.
+func (d *Deck) DrawContext(ctx context.Context) (Card, error) {
+	if err := ctx.Err(); err != nil {
+		return Card(0), err
+	}
+	return d.Draw()
+}
+
 // MustDraw removes and returns the top card from the deck.
 // It panics if the deck is empty.
 //
@@ -361,14 +1489,37 @@ func (d *Deck) MustDraw() Card {
 	return card
 }
 
+// DrawChecked removes and returns the top card from the deck, also reporting
+// whether the deck is empty after the draw. This saves a separate IsEmpty
+// call in tight game loops.
+// Returns an error if the deck is already empty, in which case empty is true.
+//
+// Example:
+//
+//	for {
+//	    card, empty, err := d.DrawChecked()
+//	    if err != nil {
+//	        break
+//	    }
+//	    play(card)
+//	    if empty {
+//	        break
+//	    }
+//	}
+func (d *Deck) DrawChecked() (card Card, empty bool, err error) {
+	card, err = d.Draw()
+	return card, d.IsEmpty(), err
+}
+
 // DrawN removes and returns n cards from the top of the deck.
+// The returned slice never shares memory with the deck, so appending to it is safe.
 // Returns an error if there are fewer than n cards in the deck.
 func (d *Deck) DrawN(n int) ([]Card, error) {
 	if n < 0 {
-		return nil, fmt.Errorf("cannot draw negative number of cards: %d", n)
+		return nil, invalidArgumentf("cannot draw negative number of cards: %d", n)
 	}
 	if n > len(d.cards) {
-		return nil, fmt.Errorf("not enough cards in deck: have %d, need %d", len(d.cards), n)
+		return nil, insufficientCardsf("not enough cards in deck: have %d, need %d", len(d.cards), n)
 	}
 
 	cards := make([]Card, n)
@@ -399,6 +1550,38 @@ func (d *Deck) MustDrawN(n int) []Card {
 	return cards
 }
 
+// BurnAndDraw discards burn cards from the top of the deck and then draws
+// the next draw cards, as when a poker dealer burns a card before the flop,
+// turn and river. The burned cards are returned for auditing.
+// If there are not enough cards or either count is negative, the deck remains
+// unchanged and an error is returned.
+//
+// Example:
+//
+//	_, flop, err := d.BurnAndDraw(1, 3)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func (d *Deck) BurnAndDraw(burn, draw int) (burned, drawn []Card, err error) {
+	if burn < 0 {
+		return nil, nil, invalidArgumentf("cannot burn negative number of cards: %d", burn)
+	}
+	if draw < 0 {
+		return nil, nil, invalidArgumentf("cannot draw negative number of cards: %d", draw)
+	}
+	if burn+draw > len(d.cards) {
+		return nil, nil, insufficientCardsf("not enough cards in deck: have %d, need %d", len(d.cards), burn+draw)
+	}
+
+	burned = make([]Card, burn)
+	copy(burned, d.cards[:burn])
+	drawn = make([]Card, draw)
+	copy(drawn, d.cards[burn:burn+draw])
+	d.cards = d.cards[burn+draw:]
+
+	return burned, drawn, nil
+}
+
 // Deal distributes cards from the deck to multiple players.
 // It removes n * cards from the top of the deck and returns
 // them as a slice of hands (each hand is a slice of Cards).
@@ -406,6 +1589,10 @@ func (d *Deck) MustDrawN(n int) []Card {
 // player 2 gets the next cards each, and so on.
 // If validation fails, the deck remains unchanged and an error is returned.
 //
+// Every hand returned by Deal and the other dealing methods has a capacity
+// equal to its length and shares no memory with the deck or another hand,
+// so callers can append to a hand, e.g. adding community cards, safely.
+//
 // Parameters:
 //   - n: number of players to deal to
 //   - cards: number of cards each player receives
@@ -425,22 +1612,127 @@ func (d *Deck) MustDrawN(n int) []Card {
 //	// hands[1] contains player 2's 5 cards
 //	// deck now has 32 cards remaining
 func (d *Deck) Deal(n, cards int) ([][]Card, error) {
+	if err := d.validateDeal(n, cards, 0); err != nil {
+		return nil, err
+	}
+
+	return d.deal(n, cards), nil
+}
+
+// ShuffleAndDeal shuffles the deck with ShuffleWithSeed(seed) and then deals
+// like Deal, so that a deterministic test can set up a game in one call. The
+// same seed, starting order and arguments always give the same hands.
+// If the deal is invalid, an error is returned and the deck is left shuffled
+// but undealt, exactly as after ShuffleWithSeed(seed).
+//
+// Example:
+//
+//	d := deck.New()
+//	hands, err := d.ShuffleAndDeal(42, 4, 5)
+//	if err != nil {
+//	    t.Fatal(err)
+//	}
+func (d *Deck) ShuffleAndDeal(seed int64, numPlayers, cardsEach int) ([][]Card, error) {
+	d.ShuffleWithSeed(seed)
+	return d.Deal(numPlayers, cardsEach)
+}
+
+// DealStrict52 deals like Deal, but first checks that the deck holds no
+// jokers, so that a standard game is never dealt from a deck built with
+// NewWithJokers by mistake. Every card of the deck is checked, not only the
+// cards that would be dealt. Use DealNoJokers to deal around the jokers instead.
+// If validation fails or the deck holds a joker, the deck remains unchanged
+// and an error is returned; the latter matches ErrJokersPresent.
+func (d *Deck) DealStrict52(numPlayers, cardsEach int) ([][]Card, error) {
+	if err := d.validateDeal(numPlayers, cardsEach, 0); err != nil {
+		return nil, err
+	}
+	if jokers := d.count(Card.IsJoker); jokers > 0 {
+		noun := "jokers"
+		if jokers == 1 {
+			noun = "joker"
+		}
+		return nil, &deckError{kind: ErrJokersPresent, msg: fmt.Sprintf("deck contains %d %s, want none", jokers, noun)}
+	}
+
+	return d.deal(numPlayers, cardsEach), nil
+}
+
+// DealSorted deals like Deal and sorts each hand in the order used by Sort:
+// by suit (Spades, Hearts, Diamonds, Clubs), then Ace through King, with
+// jokers last. Use DealSortedAceHigh when Ace ranks above King, and Deal when
+// the order in which cards were dealt matters.
+// If validation fails, the deck remains unchanged and an error is returned.
+func (d *Deck) DealSorted(numPlayers, cardsEach int) ([][]Card, error) {
+	return d.dealSorted(numPlayers, cardsEach, Card.Compare)
+}
+
+// DealSortedAceHigh is like DealSorted but sorts each suit from 2 through
+// King followed by Ace.
+func (d *Deck) DealSortedAceHigh(numPlayers, cardsEach int) ([][]Card, error) {
+	return d.dealSorted(numPlayers, cardsEach, compareAceHigh)
+}
+
+// dealSorted deals like Deal and sorts each hand with compare.
+func (d *Deck) dealSorted(numPlayers, cardsEach int, compare func(a, b Card) int) ([][]Card, error) {
+	hands, err := d.Deal(numPlayers, cardsEach)
+	if err != nil {
+		return nil, err
+	}
+	for _, hand := range hands {
+		slices.SortFunc(hand, compare)
+	}
+	return hands, nil
+}
+
+// compareAceHigh orders cards like Card.Compare but with Ace above King.
+func compareAceHigh(a, b Card) int {
+	if a.IsJoker() || b.IsJoker() || a.Suit() != b.Suit() {
+		return a.Compare(b)
+	}
+	return cmp.Compare(rankValue(a, true), rankValue(b, true))
+}
+
+// CanDeal reports how many cards would remain in the deck after
+// Deal(numPlayers, cardsEach), without dealing. It performs the same
+// validation as Deal and returns 0 and the error Deal would return if the
+// deal is not possible. The deck is not modified.
+//
+// Example:
+//
+//	if remaining, err := d.CanDeal(4, 5); err == nil {
+//	    fmt.Printf("Deal leaves %d cards in the stock\n", remaining)
+//	}
+func (d *Deck) CanDeal(numPlayers, cardsEach int) (remaining int, err error) {
+	if err := d.validateDeal(numPlayers, cardsEach, 0); err != nil {
+		return 0, err
+	}
+	return len(d.cards) - numPlayers*cardsEach, nil
+}
+
+// validateDeal checks the parameters of a deal of n hands of cards each,
+// plus extra cards dealt elsewhere (e.g. a kitty), against the deck.
+func (d *Deck) validateDeal(n, cards, extra int) error {
 	if n < 1 {
-		return nil, fmt.Errorf("number of players must be at least 1")
+		return invalidArgumentf("number of players must be at least 1")
 	}
 
 	if cards < 1 {
-		return nil, fmt.Errorf("cards per player must be at least 1")
+		return invalidArgumentf("cards per player must be at least 1")
 	}
 
-	if cards > maxCardsPerPlayer {
-		return nil, fmt.Errorf("cards per player exceeds maximum of %d", maxCardsPerPlayer)
+	// Compare by division so that huge requests cannot overflow n*cards
+	if n > (len(d.cards)-extra)/cards {
+		return insufficientCardsf("insufficient cards: need %d, have %d", n*cards+extra, len(d.cards))
 	}
 
+	return nil
+}
+
+// deal removes n hands of cards each from the top of the deck in sequential blocks.
+// The parameters must have been validated with validateDeal.
+func (d *Deck) deal(n, cards int) [][]Card {
 	totalCards := n * cards
-	if totalCards > len(d.cards) {
-		return nil, fmt.Errorf("insufficient cards: need %d, have %d", totalCards, len(d.cards))
-	}
 
 	hands := make([][]Card, n)
 	for i := 0; i < n; i++ {
@@ -454,278 +1746,2429 @@ func (d *Deck) Deal(n, cards int) ([][]Card, error) {
 
 	d.cards = d.cards[totalCards:]
 
-	return hands, nil
+	return hands
 }
 
-// MustDeal distributes cards from the deck to multiple players.
-// It panics if parameters are invalid or if there are insufficient cards.
-//
-// Cards are dealt in sequential blocks: player 1 gets the first cardsPerPlayer
-// cards, player 2 gets the next cardsPerPlayer cards, and so on.
+// HandSet is the set of hands produced by a deal, with helpers for games
+// that analyze all hands together. The dealing methods return [][]Card,
+// which converts to a HandSet without copying:
 //
-// Use MustDeal when you are certain the deck has enough cards, such as
-// dealing a bridge game from a fresh 52-card deck. For runtime error
-// handling, use Deal instead.
+//	hands, err := d.Deal(4, 5)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	set := deck.HandSet(hands)
+//	fmt.Println(set.TotalCards()) // 20
+type HandSet [][]Card
+
+// Largest returns the hand with the most cards. If several hands share the
+// largest size, the first of them is returned. Returns nil for an empty set.
+func (hs HandSet) Largest() []Card {
+	var largest []Card
+	for i, hand := range hs {
+		if i == 0 || len(hand) > len(largest) {
+			largest = hand
+		}
+	}
+	return largest
+}
+
+// TotalCards returns the number of cards across all hands.
+func (hs HandSet) TotalCards() int {
+	total := 0
+	for _, hand := range hs {
+		total += len(hand)
+	}
+	return total
+}
+
+// DealWithVisibility deals len(pattern) cards to each of numPlayers players
+// one card at a time, round-robin, as in stud poker, and reports which cards
+// are dealt face up. pattern[k] is true if each player's k-th card is face
+// up, and faceUp[i][k] gives the visibility of hands[i][k].
+// If validation fails, the deck remains unchanged and an error is returned.
 //
 // Example:
 //
-//	d := deck.New()
-//	hands := d.MustDeal(4, 13) // Bridge - safe with 52 cards
-//
-// Panics with:
-//   - "number of players must be at least 1" (if numPlayers < 1)
-//   - "cards per player must be at least 1" (if cardsPerPlayer < 1)
-//   - "number of players exceeds maximum of 26" (if numPlayers > 26)
-//   - "cards per player exceeds maximum of 52" (if cardsPerPlayer > 52)
-//   - "insufficient cards: need X, have Y" (if insufficient cards)
-func (d *Deck) MustDeal(numPlayers, cardsPerPlayer int) [][]Card {
-	hands, err := d.Deal(numPlayers, cardsPerPlayer)
+//	// Seven-card stud: two down, four up, one down
+//	pattern := []bool{false, false, true, true, true, true, false}
+//	hands, faceUp, err := d.DealWithVisibility(5, pattern)
+func (d *Deck) DealWithVisibility(numPlayers int, pattern []bool) (hands [][]Card, faceUp [][]bool, err error) {
+	hands, err = d.DealFrom(0, numPlayers, len(pattern))
 	if err != nil {
-		panic(err.Error())
+		return nil, nil, err
 	}
-	return hands
+
+	faceUp = make([][]bool, numPlayers)
+	for i := range faceUp {
+		faceUp[i] = slices.Clone(pattern)
+	}
+	return hands, faceUp, nil
 }
 
-// DealHands distributes cards from the deck to multiple players with variable hand sizes.
-// It removes sum(sizes) cards from the top of the deck and returns them as a slice
-// of hands where each hand has a different number of cards as specified in sizes.
-//
-// Cards are dealt in sequential blocks: the first player gets the first sizes[0] cards,
-// the second player gets the next sizes[1] cards, and so on.
-//
+// DealTracked deals like Deal and also reports, for each hand, the positions
+// the cards occupied in the deck before the deal, where position 0 was the
+// top card: hands[i][k] was at positions[i][k]. Combined with the permutation
+// from ShuffleTracked, a deal can be logged and replayed from positions alone.
 // If validation fails, the deck remains unchanged and an error is returned.
-//
-// Parameters:
-//   - sizes: slice of integers where sizes[i] specifies cards for player i
-//     must be non-empty, all values must be positive and ≤ 52
-//
-// Returns:
-//   - [][]Card: slice of hands where hands[i] contains sizes[i] cards
-//   - error: validation error if parameters are invalid or insufficient cards
+func (d *Deck) DealTracked(numPlayers, cardsEach int) (hands [][]Card, positions [][]int, err error) {
+	if err := d.validateDeal(numPlayers, cardsEach, 0); err != nil {
+		return nil, nil, err
+	}
+
+	positions = make([][]int, numPlayers)
+	for i := range positions {
+		positions[i] = make([]int, cardsEach)
+		for k := range positions[i] {
+			positions[i][k] = i*cardsEach + k
+		}
+	}
+
+	return d.deal(numPlayers, cardsEach), positions, nil
+}
+
+// DealNoJokers deals like Deal but skips jokers, so hands only ever contain
+// regular cards. This allows a single deck built with NewWithJokers to be
+// used for games that do not use jokers. Skipped jokers stay in the deck and
+// keep their order relative to the undealt cards.
+// If the deck does not hold enough regular cards, an error is returned and
+// the deck is unchanged.
 //
 // Example:
 //
-//	d := deck.New()
-//	// Deal casino-style: 3 players get 2 cards, dealer gets 1
-//	hands, err := d.DealHands([]int{2, 2, 2, 1})
+//	d := deck.NewWithJokers()
+//	hands, err := d.DealNoJokers(4, 13) // all 52 regular cards are dealt
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
-//	// hands[0-2] contain 2 cards each (players)
-//	// hands[3] contains 1 card (dealer)
-//	// deck now has 45 cards remaining (52 - 7 = 45)
-func (d *Deck) DealHands(handSizes []int) ([][]Card, error) {
-	// Validation: non-empty slice
-	if len(handSizes) < 1 {
-		return nil, fmt.Errorf("handSizes must contain at least one hand")
+//	// deck now holds only the 2 jokers
+func (d *Deck) DealNoJokers(numPlayers, cardsEach int) ([][]Card, error) {
+	if err := d.validateDeal(numPlayers, cardsEach, 0); err != nil {
+		return nil, err
 	}
 
-	// Calculate total cards needed and validate each hand size
-	totalCards := 0
-	for i, handSize := range handSizes {
-		if handSize <= 0 {
-			return nil, fmt.Errorf("hand size must be positive: got %d at index %d", handSize, i)
-		}
-		if handSize > maxCardsPerPlayer {
-			return nil, fmt.Errorf("hand size (%d) at index %d exceeds maximum of %d", handSize, i, maxCardsPerPlayer)
+	totalCards := numPlayers * cardsEach
+	if available := len(d.cards) - d.count(Card.IsJoker); totalCards > available {
+		return nil, insufficientCardsf("insufficient non-joker cards: need %d, have %d", totalCards, available)
+	}
+
+	dealt := make([]Card, 0, totalCards)
+	var jokers []Card
+	i := 0
+	for ; len(dealt) < totalCards; i++ {
+		if d.cards[i].IsJoker() {
+			jokers = append(jokers, d.cards[i])
+			continue
 		}
-		totalCards += handSize
+		dealt = append(dealt, d.cards[i])
 	}
 
-	// Validation: sufficient cards
-	if totalCards > len(d.cards) {
-		return nil, fmt.Errorf("insufficient cards: need %d, have %d", totalCards, len(d.cards))
+	hands := make([][]Card, numPlayers)
+	for p := range hands {
+		hands[p] = dealt[p*cardsEach : (p+1)*cardsEach : (p+1)*cardsEach]
 	}
 
-	// Allocate result slice
-	hands := make([][]Card, len(handSizes))
+	d.cards = append(jokers, d.cards[i:]...)
 
-	// Distribute cards in sequential blocks
-	offset := 0
-	for i, handSize := range handSizes {
-		hand := make([]Card, handSize)
-		copy(hand, d.cards[offset:offset+handSize])
-		hands[i] = hand
-		offset += handSize
+	return hands, nil
+}
+
+// DealMatching deals like Deal but only deals cards that satisfy the
+// predicate, taking them from the top of the deck in draw order. Cards that
+// fail the predicate before the last dealt card are removed from the deck as
+// well and returned in skipped, in the order they were found; the cards after
+// the last dealt card stay in the deck.
+// If the deck does not hold enough matching cards, validation fails or the
+// predicate panics, the deck remains unchanged; in the first two cases an
+// error is returned.
+//
+// Example:
+//
+//	isEven := func(c deck.Card) bool { return !c.IsJoker() && c.Rank()%2 == 0 }
+//	hands, setAside, err := d.DealMatching(2, 6, isEven)
+func (d *Deck) DealMatching(numPlayers, cardsEach int, pred func(Card) bool) (hands [][]Card, skipped []Card, err error) {
+	if err := d.validateDeal(numPlayers, cardsEach, 0); err != nil {
+		return nil, nil, err
 	}
 
-	// Remove dealt cards from deck
-	d.cards = d.cards[offset:]
+	totalCards := numPlayers * cardsEach
+	dealt := make([]Card, 0, totalCards)
+	i := 0
+	for ; i < len(d.cards) && len(dealt) < totalCards; i++ {
+		if pred(d.cards[i]) {
+			dealt = append(dealt, d.cards[i])
+		} else {
+			skipped = append(skipped, d.cards[i])
+		}
+	}
+	if len(dealt) < totalCards {
+		return nil, nil, insufficientCardsf("insufficient matching cards: need %d, have %d", totalCards, len(dealt))
+	}
 
-	return hands, nil
+	hands = make([][]Card, numPlayers)
+	for p := range hands {
+		hands[p] = dealt[p*cardsEach : (p+1)*cardsEach : (p+1)*cardsEach]
+	}
+
+	d.cards = d.cards[i:]
+
+	return hands, skipped, nil
 }
 
-// MustDealHands distributes cards from the deck to multiple players with variable hand sizes.
-// It panics if parameters are invalid or if there are insufficient cards.
+// DealUnique deals cardsEach cards to each of numPlayers players from a
+// multi-deck stock so that no hand holds two identical cards, whenever the
+// deck makes that possible.
 //
-// Cards are dealt in sequential blocks: the first player gets the first handSizes[0] cards,
-// the second player gets the next handSizes[1] cards, and so on.
+// Cards are taken from the top of the deck in draw order, skipping any copy
+// of a card of which numPlayers copies have already been taken, since no deal
+// can give a card to more than one hand per copy. Skipped cards stay in the
+// deck in their original order, after which the deck holds only the cards not
+// dealt. The taken cards are then dealt one at a time, round-robin, starting
+// with hands[0]; when the next card would duplicate a card in the receiving
+// hand, the first later card that does not is dealt instead and the passed
+// card goes to the next player.
 //
-// Use MustDealHands when you are certain the deck has enough cards and the hand sizes
-// are valid, such as dealing predetermined hands in a game setup. For runtime error
-// handling, use DealHands instead.
+// On the rare occasions when this reordering runs out of suitable cards for a
+// hand, DealUnique falls back to grouping identical taken cards together, in
+// the order in which each card first appears, and dealing that sequence
+// round-robin, which always succeeds. The hands then still hold the same
+// cards overall, but in a less draw-like distribution.
 //
-// Example:
+// Returns an error and leaves the deck unchanged if validation fails or the
+// deck does not hold enough cards to deal every hand without duplicates.
 //
-//	d := deck.New()
-//	hands := d.MustDealHands([]int{2, 2, 2, 1}) // Casino-style - safe with 52 cards
+// Example:
 //
-// Panics with:
-//   - "handSizes must contain at least one hand" (if slice is empty)
-//   - "hand size must be positive: got X at index Y" (if handSizes[i] <= 0)
-//   - "hand size (X) at index Y exceeds maximum of 52" (if handSizes[i] > 52)
-//   - "insufficient cards: need X, have Y" (if insufficient cards)
-func (d *Deck) MustDealHands(handSizes []int) [][]Card {
-	hands, err := d.DealHands(handSizes)
-	if err != nil {
-		panic(err.Error())
+//	d, _ := deck.NewMultiple(2)
+//	d.SecureShuffle()
+//	hands, err := d.DealUnique(4, 13) // no hand holds both copies of a card
+func (d *Deck) DealUnique(numPlayers, cardsEach int) ([][]Card, error) {
+	if err := d.validateDeal(numPlayers, cardsEach, 0); err != nil {
+		return nil, err
 	}
-	return hands
-}
 
-// Peek returns the top card without removing it from the deck.
-// Returns an error if the deck is empty.
-func (d *Deck) Peek() (Card, error) {
-	if d.IsEmpty() {
-		return Card(0), fmt.Errorf("cannot peek at empty deck")
+	totalCards := numPlayers * cardsEach
+	var copies [256]int
+	taken := make([]Card, 0, totalCards)
+	rest := make([]Card, 0, len(d.cards)-totalCards)
+	for _, card := range d.cards {
+		if len(taken) < totalCards && copies[card] < numPlayers {
+			copies[card]++
+			taken = append(taken, card)
+		} else {
+			rest = append(rest, card)
+		}
+	}
+	if len(taken) < totalCards {
+		return nil, insufficientCardsf("insufficient distinct cards: need %d, can deal %d without duplicates", totalCards, len(taken))
 	}
-	return d.cards[0], nil
-}
 
-// PeekN returns the top n cards without removing them from the deck.
-// Returns an error if there are fewer than n cards in the deck.
-func (d *Deck) PeekN(n int) ([]Card, error) {
-	if n < 0 {
-		return nil, fmt.Errorf("cannot peek negative number of cards: %d", n)
+	hands, ok := dealDistinct(taken, numPlayers, cardsEach)
+	if !ok {
+		hands = dealGrouped(taken, numPlayers, cardsEach)
 	}
-	if n > len(d.cards) {
-		return nil, fmt.Errorf("not enough cards in deck: have %d, need %d", len(d.cards), n)
+	d.cards = rest
+
+	return hands, nil
+}
+
+// dealDistinct deals cards round-robin, passing over any card already in the
+// receiving hand. It reports false if a hand cannot be completed.
+func dealDistinct(cards []Card, numPlayers, cardsEach int) ([][]Card, bool) {
+	hands := make([][]Card, numPlayers)
+	for p := range hands {
+		hands[p] = make([]Card, 0, cardsEach)
 	}
 
-	cards := make([]Card, n)
-	copy(cards, d.cards[:n])
-	return cards, nil
+	pending := slices.Clone(cards)
+	for i := range cards {
+		hand := hands[i%numPlayers]
+		j := slices.IndexFunc(pending, func(c Card) bool { return !slices.Contains(hand, c) })
+		if j < 0 {
+			return nil, false
+		}
+		hands[i%numPlayers] = append(hand, pending[j])
+		pending = slices.Delete(pending, j, j+1)
+	}
+	return hands, true
 }
 
-// Add adds a card to the bottom of the deck.
-func (d *Deck) Add(card Card) {
-	d.cards = append(d.cards, card)
+// dealGrouped deals cards round-robin after grouping identical cards in order
+// of first appearance. Since no card has more than numPlayers copies, the
+// copies of a card land in distinct hands.
+func dealGrouped(cards []Card, numPlayers, cardsEach int) [][]Card {
+	var copies [256]int
+	order := make([]Card, 0, len(cards))
+	for _, card := range cards {
+		if copies[card] == 0 {
+			order = append(order, card)
+		}
+		copies[card]++
+	}
+
+	hands := make([][]Card, numPlayers)
+	for p := range hands {
+		hands[p] = make([]Card, 0, cardsEach)
+	}
+	i := 0
+	for _, card := range order {
+		for range copies[card] {
+			hands[i%numPlayers] = append(hands[i%numPlayers], card)
+			i++
+		}
+	}
+	return hands
 }
 
-// AddJoker adds a joker card to the bottom of the deck.
-// The rank parameter should be RedJoker or BlackJoker.
-func (d *Deck) AddJoker(rank Rank) {
-	var joker Card
-	if rank == RedJoker {
-		joker = NewRedJoker()
-	} else {
-		joker = NewBlackJoker()
+// SplitDeal deals like Deal but leaves the receiver untouched, returning the
+// hands together with a new deck holding the remaining cards. The hands and
+// the remainder do not share memory with the receiver, which suits immutable
+// game-state designs.
+//
+// Example:
+//
+//	hands, stock, err := d.SplitDeal(4, 5)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	// d still has all of its cards; stock has d.Len()-20 cards
+func (d *Deck) SplitDeal(numPlayers, cardsEach int) (hands [][]Card, remainder *Deck, err error) {
+	if err := d.validateDeal(numPlayers, cardsEach, 0); err != nil {
+		return nil, nil, err
 	}
-	d.Add(joker)
+
+	clone := &Deck{cards: d.cards}
+	hands = clone.deal(numPlayers, cardsEach)
+
+	return hands, &Deck{cards: clone.Cards()}, nil
 }
 
-// AddToTop adds a card to the top of the deck.
-func (d *Deck) AddToTop(card Card) {
-	d.cards = append([]Card{card}, d.cards...)
+// DealFrom deals cardsEach cards to each of numPlayers players one card at a
+// time, round-robin, starting with the seat startPlayer and wrapping around,
+// as in a real deal that starts to the left of the dealer.
+// The returned hands are indexed by seat, so hands[startPlayer] receives the
+// top card, hands[(startPlayer+1)%numPlayers] the next one, and so on.
+// If validation fails, the deck remains unchanged and an error is returned.
+//
+// Example:
+//
+//	d := deck.New()
+//	dealer := 2
+//	hands, err := d.DealFrom((dealer+1)%4, 4, 13) // Bridge, starting left of the dealer
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	// hands[3] received the top card
+func (d *Deck) DealFrom(startPlayer, numPlayers, cardsEach int) ([][]Card, error) {
+	if err := d.validateDeal(numPlayers, cardsEach, 0); err != nil {
+		return nil, err
+	}
+	if startPlayer < 0 || startPlayer >= numPlayers {
+		return nil, invalidArgumentf("start player must be between 0 and %d, got %d", numPlayers-1, startPlayer)
+	}
+
+	hands := make([][]Card, numPlayers)
+	for i := range hands {
+		hands[i] = make([]Card, cardsEach)
+	}
+
+	for i := 0; i < numPlayers*cardsEach; i++ {
+		seat := (startPlayer + i) % numPlayers
+		hands[seat][i/numPlayers] = d.cards[i]
+	}
+
+	d.cards = d.cards[numPlayers*cardsEach:]
+
+	return hands, nil
 }
 
-// Sort sorts the deck by suit (Spades, Hearts, Diamonds, Clubs) and then by rank.
-// Jokers are sorted to the end of the deck (Red Joker before Black Joker).
-func (d *Deck) Sort() {
-	sort.Slice(d.cards, func(i, j int) bool {
-		iRank, jRank := d.cards[i].Rank(), d.cards[j].Rank()
-		iJoker, jJoker := iRank >= RedJoker, jRank >= RedJoker
+// DealRounds deals in several passes around the table, as in games dealt
+// "three, then two": in round r every player in turn receives a packet of
+// perRound[r] consecutive cards from the top of the deck, starting with
+// hands[0]. Each player ends up with the sum of perRound cards, in the order
+// received. DealRounds(n, []int{1, 1, 1}) deals like DealFrom(0, n, 3), while
+// DealRounds(n, []int{3}) deals like Deal(n, 3).
+// If validation fails, the deck remains unchanged and an error is returned.
+//
+// Example:
+//
+//	d := deck.New()
+//	hands, err := d.DealRounds(4, []int{3, 2}) // 5 cards each, dealt 3 then 2
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func (d *Deck) DealRounds(numPlayers int, perRound []int) ([][]Card, error) {
+	if len(perRound) < 1 {
+		return nil, invalidArgumentf("perRound must contain at least one round")
+	}
 
-		// If one is a joker and the other isn't, non-joker comes first
-		if iJoker != jJoker {
-			return !iJoker // non-joker (false) comes before joker (true)
+	cardsEach := 0
+	for r, packet := range perRound {
+		if packet < 1 {
+			return nil, invalidArgumentf("cards per round must be at least 1, got %d in round %d", packet, r)
 		}
-
-		// If both are jokers, sort by rank (Red Joker=14 < Black Joker=15)
-		if iJoker && jJoker {
-			return iRank < jRank
+		// Reject oversized packets early so that the sum cannot overflow
+		if packet > len(d.cards) {
+			return nil, insufficientCardsf("insufficient cards: round %d needs %d cards per player, have %d", r, packet, len(d.cards))
 		}
+		cardsEach += packet
+	}
+	if err := d.validateDeal(numPlayers, cardsEach, 0); err != nil {
+		return nil, err
+	}
+
+	hands := make([][]Card, numPlayers)
+	for i := range hands {
+		hands[i] = make([]Card, 0, cardsEach)
+	}
 
-		// Regular cards: sort by suit, then rank
-		if d.cards[i].Suit() != d.cards[j].Suit() {
-			return d.cards[i].Suit() < d.cards[j].Suit()
+	offset := 0
+	for _, packet := range perRound {
+		for p := range hands {
+			hands[p] = append(hands[p], d.cards[offset:offset+packet]...)
+			offset += packet
 		}
-		return iRank < jRank
-	})
+	}
+
+	d.cards = d.cards[offset:]
+
+	return hands, nil
 }
 
-// Cards returns a copy of all cards in the deck.
-// The returned slice is a copy to prevent external modification.
-func (d *Deck) Cards() []Card {
-	cards := make([]Card, len(d.cards))
-	copy(cards, d.cards)
-	return cards
+// DealSkipping deals cardsEach cards one card at a time, round-robin, to the
+// seats marked true in active, passing over seats that sit out the hand.
+// Seats keep their positions in the rotation, so the first active seat
+// receives the top card, the next active seat the following one, and so on.
+// The returned hands are indexed by seat, with a nil hand for each inactive seat.
+// If validation fails, the deck remains unchanged and an error is returned.
+//
+// Example:
+//
+//	// Seat 1 sits out; seats 0, 2 and 3 receive 5 cards each
+//	hands, err := d.DealSkipping(5, []bool{true, false, true, true})
+func (d *Deck) DealSkipping(cardsEach int, active []bool) ([][]Card, error) {
+	var seats []int
+	for seat, ok := range active {
+		if ok {
+			seats = append(seats, seat)
+		}
+	}
+	if len(seats) == 0 {
+		return nil, invalidArgumentf("at least one seat must be active")
+	}
+	if err := d.validateDeal(len(seats), cardsEach, 0); err != nil {
+		return nil, err
+	}
+
+	hands := make([][]Card, len(active))
+	for _, seat := range seats {
+		hands[seat] = make([]Card, cardsEach)
+	}
+
+	for i := 0; i < len(seats)*cardsEach; i++ {
+		hands[seats[i%len(seats)]][i/len(seats)] = d.cards[i]
+	}
+
+	d.cards = d.cards[len(seats)*cardsEach:]
+
+	return hands, nil
 }
 
-// String returns a string representation of the deck.
-func (d *Deck) String() string {
-	if d.IsEmpty() {
-		return "Empty Deck"
+// DealAll deals the entire deck to numPlayers players one card at a time,
+// round-robin, as in War. The first players receive one extra card when the
+// deck does not divide evenly, so hand sizes differ by at most one.
+// The deck is empty afterwards. Returns an error if numPlayers is less than 1.
+//
+// Example:
+//
+//	d := deck.New()
+//	hands, err := d.DealAll(3) // hands of 18, 17 and 17 cards
+func (d *Deck) DealAll(numPlayers int) ([][]Card, error) {
+	if numPlayers < 1 {
+		return nil, invalidArgumentf("number of players must be at least 1")
 	}
 
-	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("Deck (%d cards): [", len(d.cards)))
-	for i, card := range d.cards {
-		if i > 0 {
-			sb.WriteString(", ")
+	hands := make([][]Card, numPlayers)
+	for i := range hands {
+		size := len(d.cards) / numPlayers
+		if i < len(d.cards)%numPlayers {
+			size++
 		}
-		sb.WriteString(card.ShortString())
+		hands[i] = make([]Card, size)
 	}
-	sb.WriteString("]")
-	return sb.String()
+
+	for i, card := range d.cards {
+		hands[i%numPlayers][i/numPlayers] = card
+	}
+
+	d.cards = d.cards[len(d.cards):]
+
+	return hands, nil
 }
 
-// Filter applies a filter function to create a new deck containing only cards
-// that satisfy the predicate.
-func (d *Deck) Filter(predicate func(Card) bool) *Deck {
-	filtered := make([]Card, 0, len(d.cards))
-	for _, card := range d.cards {
-		if predicate(card) {
-			filtered = append(filtered, card)
+// DealFunc deals cardsEach cards to each of numPlayers players one card at a
+// time, round-robin, and calls onDeal for every card in the order it is
+// dealt: player 0's first card, player 1's first card, and so on. This lets
+// a UI animate each card as it leaves the deck. A nil onDeal is ignored.
+// The parameters are validated before onDeal is first called; if validation
+// fails or onDeal panics, the deck remains unchanged.
+//
+// Example:
+//
+//	hands, err := d.DealFunc(4, 5, func(player int, c deck.Card) {
+//	    animateDeal(player, c)
+//	})
+func (d *Deck) DealFunc(numPlayers, cardsEach int, onDeal func(player int, c Card)) ([][]Card, error) {
+	if err := d.validateDeal(numPlayers, cardsEach, 0); err != nil {
+		return nil, err
+	}
+
+	hands := make([][]Card, numPlayers)
+	for i := range hands {
+		hands[i] = make([]Card, cardsEach)
+	}
+
+	for i := 0; i < numPlayers*cardsEach; i++ {
+		player := i % numPlayers
+		hands[player][i/numPlayers] = d.cards[i]
+		if onDeal != nil {
+			onDeal(player, d.cards[i])
 		}
 	}
-	return &Deck{cards: filtered}
+
+	d.cards = d.cards[numPlayers*cardsEach:]
+
+	return hands, nil
 }
 
-// MarshalBinary implements encoding.BinaryMarshaler.
-// This provides efficient binary encoding for network transfer.
-// Format: 4 bytes for length (uint32) + 1 byte per card.
-func (d *Deck) MarshalBinary() ([]byte, error) {
-	// 4 bytes for length + 1 byte per card
-	data := make([]byte, 4+len(d.cards))
-	binary.LittleEndian.PutUint32(data[0:4], uint32(len(d.cards)))
-	for i, card := range d.cards {
-		data[4+i] = byte(card)
+// DealHoldem performs the full Texas Hold'em dealing sequence: two hole cards
+// to each of numPlayers players, dealt one at a time round-robin starting with
+// holes[0], then a burn card and the three-card flop, a burn card and the
+// turn, and a burn card and the river. The burn cards are discarded.
+// In total 2*numPlayers+8 cards are removed from the top of the deck.
+// If validation fails, the deck remains unchanged and an error is returned.
+//
+// Example:
+//
+//	d := deck.New()
+//	d.SecureShuffle()
+//	holes, flop, turn, river, err := d.DealHoldem(6)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	board := append(append(flop, turn...), river...)
+func (d *Deck) DealHoldem(numPlayers int) (holes [][]Card, flop, turn, river []Card, err error) {
+	if err := d.validateDeal(numPlayers, 2, 8); err != nil {
+		return nil, nil, nil, nil, err
 	}
-	return data, nil
+
+	// The errors below cannot occur since the deal has been validated
+	holes, _ = d.DealFrom(0, numPlayers, 2)
+	_, flop, _ = d.BurnAndDraw(1, 3)
+	_, turn, _ = d.BurnAndDraw(1, 1)
+	_, river, _ = d.BurnAndDraw(1, 1)
+
+	return holes, flop, turn, river, nil
 }
 
-// UnmarshalBinary implements encoding.BinaryUnmarshaler.
-// This decodes the binary format produced by MarshalBinary.
-func (d *Deck) UnmarshalBinary(data []byte) error {
-	if len(data) < 4 {
-		return fmt.Errorf("invalid data: too short")
+// DealBridge deals a 52-card deck to the four bridge seats, 13 cards each,
+// one card at a time, round-robin, clockwise from North to East, South and
+// West. startSeat is the seat receiving the top card, where 0 is North,
+// 1 East, 2 South and 3 West. Each hand is sorted as by Sort.
+// The deck must hold exactly 52 cards. If validation fails, the deck remains
+// unchanged and an error is returned.
+//
+// Example:
+//
+//	d := deck.New()
+//	d.SecureShuffle()
+//	dealer := 3 // West deals, so North receives the first card
+//	n, e, s, w, err := d.DealBridge((dealer + 1) % 4)
+func (d *Deck) DealBridge(startSeat int) (north, east, south, west []Card, err error) {
+	if len(d.cards) < 52 {
+		return nil, nil, nil, nil, insufficientCardsf("insufficient cards: need 52, have %d", len(d.cards))
+	}
+	if len(d.cards) > 52 {
+		return nil, nil, nil, nil, invalidArgumentf("bridge requires exactly 52 cards, have %d", len(d.cards))
 	}
 
-	count := binary.LittleEndian.Uint32(data[0:4])
-	if len(data) != int(4+count) {
-		return fmt.Errorf("invalid data: expected %d bytes, got %d", 4+count, len(data))
+	hands, err := d.DealFrom(startSeat, 4, 13)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	for _, hand := range hands {
+		slices.SortFunc(hand, Card.Compare)
 	}
 
-	d.cards = make([]Card, count)
-	for i := uint32(0); i < count; i++ {
-		d.cards[i] = Card(data[4+i])
+	return hands[0], hands[1], hands[2], hands[3], nil
+}
+
+// DealWithKitty deals cardsEach cards to each of numPlayers players and then
+// sets aside kitty cards, as in games with a kitty or widow (e.g. Euchre).
+// Players are dealt first in sequential blocks, as with Deal, and the kitty
+// receives the next kitty cards. A kitty of 0 yields an empty kitty.
+// If validation fails, the deck remains unchanged and an error is returned.
+//
+// Example:
+//
+//	d := deck.New()
+//	hands, kitty, err := d.DealWithKitty(4, 12, 4) // 4 hands of 12 and a kitty of 4
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	// deck is now empty
+func (d *Deck) DealWithKitty(numPlayers, cardsEach, kitty int) (hands [][]Card, kittyCards []Card, err error) {
+	if kitty < 0 {
+		return nil, nil, invalidArgumentf("kitty size must not be negative: %d", kitty)
 	}
-	return nil
+	if err := d.validateDeal(numPlayers, cardsEach, kitty); err != nil {
+		return nil, nil, err
+	}
+
+	hands = d.deal(numPlayers, cardsEach)
+
+	kittyCards = make([]Card, kitty)
+	copy(kittyCards, d.cards[:kitty])
+	d.cards = d.cards[kitty:]
+
+	return hands, kittyCards, nil
 }
 
-// Size returns the byte size of the deck when marshaled.
-// This is useful for network transfer size estimation.
-func (d *Deck) Size() int {
-	return 4 + len(d.cards) // 4 bytes header + 1 byte per card
+// ExchangeWithKitty lets the winner of the bid pick up the kitty and discard
+// back down to the original hand size, as in Euchre or 500. Every card of
+// discard must come from hand or kitty, and exactly len(kitty) cards must be
+// discarded. The exchange happens in place: afterwards hand holds its kept
+// cards in their original order followed by the kept kitty cards, and kitty
+// holds the discarded cards in the order given.
+// If validation fails, hand and kitty remain unchanged and an error is returned.
+//
+// Example:
+//
+//	hands, kitty, _ := d.DealWithKitty(4, 5, 4)
+//	// Seat 2 wins the bid and buries four cards
+//	err := deck.ExchangeWithKitty(hands[2], kitty, []deck.Card{low1, low2, low3, low4})
+func ExchangeWithKitty(hand, kitty, discard []Card) error {
+	if len(discard) != len(kitty) {
+		return invalidArgumentf("must discard %d cards to match the kitty, got %d", len(kitty), len(discard))
+	}
+
+	// remaining[c] is the number of copies of c not yet matched to a discard
+	var remaining [256]int
+	for _, card := range hand {
+		remaining[card]++
+	}
+	for _, card := range kitty {
+		remaining[card]++
+	}
+	for i, card := range discard {
+		if remaining[card] == 0 {
+			return invalidArgumentf("discard %d (%v) is not in the hand or the kitty", i, card)
+		}
+		remaining[card]--
+	}
+
+	// Drop the first copies of the discarded cards, keeping the rest in order
+	var dropped [256]int
+	for _, card := range discard {
+		dropped[card]++
+	}
+	kept := make([]Card, 0, len(hand))
+	for _, card := range slices.Concat(hand, kitty) {
+		if dropped[card] > 0 {
+			dropped[card]--
+			continue
+		}
+		kept = append(kept, card)
+	}
+
+	// discard may alias hand or kitty, so copy it before writing
+	buried := slices.Clone(discard)
+	copy(hand, kept)
+	copy(kitty, buried)
+	return nil
+}
+
+// SetupDraw performs the common table setup of draw games such as Rummy:
+// it deals cardsEach cards to each of numPlayers players, turns the next card
+// face up to start the discard pile, and moves all remaining cards into a new
+// face-down stock. Hands are dealt in sequential blocks, as with Deal.
+// After a successful call the deck is empty and play continues from stock.
+// If validation fails, the deck remains unchanged and an error is returned.
+//
+// Example:
+//
+//	d := deck.New()
+//	d.SecureShuffle()
+//	hands, stock, discardTop, err := d.SetupDraw(4, 7)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	// 4 hands of 7 cards, 1 discard and a stock of 23 cards
+func (d *Deck) SetupDraw(numPlayers, cardsEach int) (hands [][]Card, stock *Deck, discardTop Card, err error) {
+	if err := d.validateDeal(numPlayers, cardsEach, 1); err != nil {
+		return nil, nil, Card(0), err
+	}
+
+	hands = d.deal(numPlayers, cardsEach)
+	discardTop = d.cards[0]
+
+	stock = &Deck{cards: make([]Card, len(d.cards)-1)}
+	copy(stock.cards, d.cards[1:])
+	d.cards = d.cards[len(d.cards):]
+
+	return hands, stock, discardTop, nil
+}
+
+// MustDeal distributes cards from the deck to multiple players.
+// It panics if parameters are invalid or if there are insufficient cards.
+//
+// Cards are dealt in sequential blocks: player 1 gets the first cardsPerPlayer
+// cards, player 2 gets the next cardsPerPlayer cards, and so on.
+//
+// Use MustDeal when you are certain the deck has enough cards, such as
+// dealing a bridge game from a fresh 52-card deck. For runtime error
+// handling, use Deal instead.
+//
+// Example:
+//
+//	d := deck.New()
+//	hands := d.MustDeal(4, 13) // Bridge - safe with 52 cards
+//
+// Panics with:
+//   - "number of players must be at least 1" (if numPlayers < 1)
+//   - "cards per player must be at least 1" (if cardsPerPlayer < 1)
+//   - "insufficient cards: need X, have Y" (if insufficient cards)
+func (d *Deck) MustDeal(numPlayers, cardsPerPlayer int) [][]Card {
+	hands, err := d.Deal(numPlayers, cardsPerPlayer)
+	if err != nil {
+		panic(err.Error())
+	}
+	return hands
+}
+
+// DealHands distributes cards from the deck to multiple players with variable hand sizes.
+// It removes sum(sizes) cards from the top of the deck and returns them as a slice
+// of hands where each hand has a different number of cards as specified in sizes.
+//
+// Cards are dealt in sequential blocks: the first player gets the first sizes[0] cards,
+// the second player gets the next sizes[1] cards, and so on.
+//
+// If validation fails, the deck remains unchanged and an error is returned.
+//
+// Parameters:
+//   - sizes: slice of integers where sizes[i] specifies cards for player i
+//     must be non-empty, all values must be positive and at most Len()
+//
+// Returns:
+//   - [][]Card: slice of hands where hands[i] contains sizes[i] cards
+//   - error: validation error if parameters are invalid or insufficient cards
+//
+// Example:
+//
+//	d := deck.New()
+//	// Deal casino-style: 3 players get 2 cards, dealer gets 1
+//	hands, err := d.DealHands([]int{2, 2, 2, 1})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	// hands[0-2] contain 2 cards each (players)
+//	// hands[3] contains 1 card (dealer)
+//	// deck now has 45 cards remaining (52 - 7 = 45)
+func (d *Deck) DealHands(handSizes []int) ([][]Card, error) {
+	// Validation: non-empty slice
+	if len(handSizes) < 1 {
+		return nil, invalidArgumentf("handSizes must contain at least one hand")
+	}
+
+	// Calculate total cards needed and validate each hand size
+	totalCards := 0
+	for i, handSize := range handSizes {
+		if err := validateHandSize(i, handSize, len(d.cards)); err != nil {
+			return nil, err
+		}
+		totalCards += handSize
+	}
+
+	// Validation: sufficient cards
+	if totalCards > len(d.cards) {
+		return nil, insufficientCardsf("insufficient cards: need %d, have %d", totalCards, len(d.cards))
+	}
+
+	// Allocate result slice
+	hands := make([][]Card, len(handSizes))
+
+	// Distribute cards in sequential blocks
+	offset := 0
+	for i, handSize := range handSizes {
+		hand := make([]Card, handSize)
+		copy(hand, d.cards[offset:offset+handSize])
+		hands[i] = hand
+		offset += handSize
+	}
+
+	// Remove dealt cards from deck
+	d.cards = d.cards[offset:]
+
+	return hands, nil
+}
+
+// MustDealHands distributes cards from the deck to multiple players with variable hand sizes.
+// It panics if parameters are invalid or if there are insufficient cards.
+//
+// Cards are dealt in sequential blocks: the first player gets the first handSizes[0] cards,
+// the second player gets the next handSizes[1] cards, and so on.
+//
+// Use MustDealHands when you are certain the deck has enough cards and the hand sizes
+// are valid, such as dealing predetermined hands in a game setup. For runtime error
+// handling, use DealHands instead.
+//
+// Example:
+//
+//	d := deck.New()
+//	hands := d.MustDealHands([]int{2, 2, 2, 1}) // Casino-style - safe with 52 cards
+//
+// Panics with:
+//   - "handSizes must contain at least one hand" (if slice is empty)
+//   - "hand size must be positive: got X at index Y" (if handSizes[i] <= 0)
+//   - "hand size (X) at index Y exceeds deck size of Z" (if handSizes[i] > Len())
+//   - "insufficient cards: need X, have Y" (if insufficient cards)
+func (d *Deck) MustDealHands(handSizes []int) [][]Card {
+	hands, err := d.DealHands(handSizes)
+	if err != nil {
+		panic(err.Error())
+	}
+	return hands
+}
+
+// validateHandSize checks the size of the hand at index i for DealHands and
+// DealInto against a deck of deckSize cards.
+func validateHandSize(i, handSize, deckSize int) error {
+	if handSize <= 0 {
+		return invalidArgumentf("hand size must be positive: got %d at index %d", handSize, i)
+	}
+	if handSize > deckSize {
+		return insufficientCardsf("hand size (%d) at index %d exceeds deck size of %d", handSize, i, deckSize)
+	}
+	return nil
+}
+
+// DealInto distributes cards from the deck into caller-provided hands,
+// overwriting their contents. Each hand receives len(hands[i]) cards, dealt
+// in sequential blocks as with DealHands. No memory is allocated, so the same
+// hands can be reused across many deals in high-frequency simulations.
+// If validation fails, the deck and the hands remain unchanged and an error is returned.
+//
+// Example:
+//
+//	hands := [][]deck.Card{make([]deck.Card, 2), make([]deck.Card, 2)}
+//	for i := 0; i < trials; i++ {
+//	    _ = d.Restore(snap)
+//	    if err := d.DealInto(hands); err != nil {
+//	        log.Fatal(err)
+//	    }
+//	    // evaluate hands...
+//	}
+func (d *Deck) DealInto(hands [][]Card) error {
+	if len(hands) < 1 {
+		return invalidArgumentf("hands must contain at least one hand")
+	}
+
+	totalCards := 0
+	for i, hand := range hands {
+		if err := validateHandSize(i, len(hand), len(d.cards)); err != nil {
+			return err
+		}
+		totalCards += len(hand)
+	}
+
+	if totalCards > len(d.cards) {
+		return insufficientCardsf("insufficient cards: need %d, have %d", totalCards, len(d.cards))
+	}
+
+	offset := 0
+	for _, hand := range hands {
+		offset += copy(hand, d.cards[offset:])
+	}
+	d.cards = d.cards[offset:]
+
+	return nil
+}
+
+// DrawChunks draws the entire remaining deck into consecutive chunks of chunkSize cards.
+// The final chunk holds the leftover cards and may be smaller than chunkSize.
+// Unlike Deal, the number of chunks does not need to be known up front.
+// If validation fails, the deck remains unchanged and an error is returned.
+//
+// Example:
+//
+//	d := deck.New()
+//	rows, err := d.DrawChunks(7) // 7 rows of 7 cards and a final row of 3
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	// deck is now empty
+func (d *Deck) DrawChunks(chunkSize int) ([][]Card, error) {
+	if chunkSize < 1 {
+		return nil, invalidArgumentf("chunk size must be at least 1, got %d", chunkSize)
+	}
+
+	chunks := make([][]Card, 0, (len(d.cards)+chunkSize-1)/chunkSize)
+	for offset := 0; offset < len(d.cards); offset += chunkSize {
+		end := min(offset+chunkSize, len(d.cards))
+
+		chunk := make([]Card, end-offset)
+		copy(chunk, d.cards[offset:end])
+		chunks = append(chunks, chunk)
+	}
+
+	d.cards = d.cards[len(d.cards):]
+
+	return chunks, nil
+}
+
+// DrawWhile draws cards from the top of the deck for as long as they satisfy
+// the predicate. The first card that fails the predicate is not drawn and
+// becomes the new top card; if every card satisfies it, the whole deck is
+// drawn. The returned slice is empty, not nil, when the top card fails.
+// Returns an error if the deck is empty. If the predicate panics, the deck
+// remains unchanged.
+//
+// Example:
+//
+//	// Draw until a face card turns up, leaving it on the deck
+//	isNumber := func(c deck.Card) bool { return c.Rank() >= deck.Two && c.Rank() <= deck.Ten }
+//	numbers, err := d.DrawWhile(isNumber)
+func (d *Deck) DrawWhile(pred func(Card) bool) ([]Card, error) {
+	if d.IsEmpty() {
+		return nil, insufficientCardsf("cannot draw from empty deck")
+	}
+
+	n, _, found := d.FindFunc(func(c Card) bool { return !pred(c) })
+	if !found {
+		n = len(d.cards)
+	}
+	return d.DrawN(n)
+}
+
+// DrawUntil draws cards from the top of the deck up to and including the
+// first card that satisfies the predicate. That card is returned as stopper
+// and the cards drawn before it as drawn, which is empty, not nil, when the
+// top card matches. Both are removed from the deck.
+// If no card satisfies the predicate or the predicate panics, the deck
+// remains unchanged; in the former case an error is returned.
+//
+// Example:
+//
+//	// Deal until an Ace turns up
+//	before, ace, err := d.DrawUntil(func(c deck.Card) bool { return c.Rank() == deck.Ace })
+func (d *Deck) DrawUntil(pred func(Card) bool) (drawn []Card, stopper Card, err error) {
+	n, stopper, found := d.FindFunc(pred)
+	if !found {
+		return nil, Card(0), insufficientCardsf("no card in deck satisfies the predicate: searched %d cards", len(d.cards))
+	}
+
+	drawn = make([]Card, n)
+	copy(drawn, d.cards[:n])
+	d.cards = d.cards[n+1:]
+	return drawn, stopper, nil
+}
+
+// Peek returns the top card without removing it from the deck.
+// Returns an error if the deck is empty.
+func (d *Deck) Peek() (Card, error) {
+	if d.IsEmpty() {
+		return Card(0), insufficientCardsf("cannot peek at empty deck")
+	}
+	return d.cards[0], nil
+}
+
+// Position identifies an end of the deck for DrawFrom and PeekFrom.
+type Position uint8
+
+const (
+	// Top is the end that Draw and Peek use.
+	Top Position = iota
+	// Bottom is the end that Add appends to.
+	Bottom
+)
+
+// String returns the string representation of a Position.
+func (p Position) String() string {
+	switch p {
+	case Top:
+		return "Top"
+	case Bottom:
+		return "Bottom"
+	}
+	return fmt.Sprintf("Position(%d)", uint8(p))
+}
+
+// DrawFrom removes and returns the card at position p, so that code can
+// parameterize over the end it deals from, as in games that alternate.
+// DrawFrom(Top) is equivalent to Draw.
+// Returns an error if p is not Top or Bottom, or if the deck is empty.
+//
+// Example:
+//
+//	end := deck.Top
+//	if round%2 == 1 {
+//	    end = deck.Bottom
+//	}
+//	card, err := d.DrawFrom(end)
+func (d *Deck) DrawFrom(p Position) (Card, error) {
+	if p > Bottom {
+		return Card(0), invalidArgumentf("invalid position: %v", p)
+	}
+	if p == Top {
+		return d.Draw()
+	}
+	if d.IsEmpty() {
+		return Card(0), insufficientCardsf("cannot draw from empty deck")
+	}
+
+	last := len(d.cards) - 1
+	card := d.cards[last]
+	d.cards = d.cards[:last]
+	return card, nil
+}
+
+// PeekFrom returns the card at position p without removing it from the deck.
+// PeekFrom(Top) is equivalent to Peek.
+// Returns an error if p is not Top or Bottom, or if the deck is empty.
+func (d *Deck) PeekFrom(p Position) (Card, error) {
+	if p > Bottom {
+		return Card(0), invalidArgumentf("invalid position: %v", p)
+	}
+	if d.IsEmpty() {
+		return Card(0), insufficientCardsf("cannot peek at empty deck")
+	}
+	if p == Top {
+		return d.cards[0], nil
+	}
+	return d.cards[len(d.cards)-1], nil
+}
+
+// CutReveal cuts the deck at a random position chosen by shuffler and returns
+// the card found there, as when cutting for the starter in cribbage.
+// The deck is left unchanged: the starter stays in place, so callers that
+// want it out of play should remove it themselves.
+// Every position is equally likely when shuffler produces uniform shuffles.
+// Returns an error if the deck is empty.
+//
+// Example:
+//
+//	starter, err := d.CutReveal(deck.SecureShuffler{})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if starter.Rank() == deck.Jack {
+//	    // the dealer pegs 2
+//	}
+func (d *Deck) CutReveal(shuffler Shuffler) (starter Card, err error) {
+	if d.IsEmpty() {
+		return Card(0), insufficientCardsf("cannot cut empty deck")
+	}
+
+	return d.cards[randomIndex(len(d.cards), shuffler)], nil
+}
+
+// TakeRandom removes and returns a card chosen at random by shuffler from
+// anywhere in the deck, unlike Draw which always takes the top card.
+// The remaining cards keep their order. Every card is equally likely when
+// shuffler produces uniform shuffles.
+// Returns an error if the deck is empty.
+func (d *Deck) TakeRandom(shuffler Shuffler) (Card, error) {
+	if d.IsEmpty() {
+		return Card(0), insufficientCardsf("cannot take from empty deck")
+	}
+
+	i := randomIndex(len(d.cards), shuffler)
+	card := d.cards[i]
+	d.cards = append(d.cards[:i], d.cards[i+1:]...)
+	return card, nil
+}
+
+// randomIndex returns a random index in [0, n) derived from a shuffle of n
+// elements, by following the element starting at position 0 to its final
+// position. It does not allocate.
+func randomIndex(n int, shuffler Shuffler) int {
+	idx := 0
+	shuffler.Shuffle(n, func(i, j int) {
+		switch idx {
+		case i:
+			idx = j
+		case j:
+			idx = i
+		}
+	})
+	return idx
+}
+
+// PeekN returns the top n cards without removing them from the deck.
+// The returned slice never shares memory with the deck, so appending to it is safe.
+// Returns an error if there are fewer than n cards in the deck.
+func (d *Deck) PeekN(n int) ([]Card, error) {
+	if n < 0 {
+		return nil, invalidArgumentf("cannot peek negative number of cards: %d", n)
+	}
+	if n > len(d.cards) {
+		return nil, insufficientCardsf("not enough cards in deck: have %d, need %d", len(d.cards), n)
+	}
+
+	cards := make([]Card, n)
+	copy(cards, d.cards[:n])
+	return cards, nil
+}
+
+// PeekIndices returns the cards at the given positions without modifying the
+// deck, where position 0 is the top card. The result has one card per index,
+// in the order of indices, and indices may repeat.
+// Returns an error if any index is out of range.
+func (d *Deck) PeekIndices(indices []int) ([]Card, error) {
+	cards := make([]Card, len(indices))
+	for i, idx := range indices {
+		if idx < 0 || idx >= len(d.cards) {
+			return nil, invalidArgumentf("index out of range: %d (deck has %d cards)", idx, len(d.cards))
+		}
+		cards[i] = d.cards[idx]
+	}
+	return cards, nil
+}
+
+// PeekOr returns the top card without removing it from the deck, or fallback
+// if the deck is empty. It suits display code where an empty deck is a normal
+// state rather than an error; game logic should use Peek instead.
+func (d *Deck) PeekOr(fallback Card) Card {
+	if d.IsEmpty() {
+		return fallback
+	}
+	return d.cards[0]
+}
+
+// PeekBottomOr returns the bottom card without removing it from the deck, or
+// fallback if the deck is empty.
+func (d *Deck) PeekBottomOr(fallback Card) Card {
+	if d.IsEmpty() {
+		return fallback
+	}
+	return d.cards[len(d.cards)-1]
+}
+
+// Add adds a card to the bottom of the deck.
+func (d *Deck) Add(card Card) {
+	d.cards = append(d.cards, card)
+}
+
+// AddJoker adds a joker card to the bottom of the deck.
+// The rank parameter should be RedJoker or BlackJoker.
+func (d *Deck) AddJoker(rank Rank) {
+	var joker Card
+	if rank == RedJoker {
+		joker = NewRedJoker()
+	} else {
+		joker = NewBlackJoker()
+	}
+	d.Add(joker)
+}
+
+// AddToTop adds a card to the top of the deck.
+func (d *Deck) AddToTop(card Card) {
+	d.cards = append([]Card{card}, d.cards...)
+}
+
+// InsertAll inserts cards, in order, before the card at position index, so
+// that cards[0] ends up at position index. Position 0 is the top of the deck
+// and an index of Len() appends the cards to the bottom. It can return a
+// drawn batch to a given depth or reassemble a cut in a single splice.
+// Returns an error if index is out of range, leaving the deck unchanged.
+//
+// Example:
+//
+//	drawn, _ := d.DrawN(3)
+//	_ = d.InsertAll(5, drawn) // put them back five cards deep
+func (d *Deck) InsertAll(index int, cards []Card) error {
+	if index < 0 || index > len(d.cards) {
+		return invalidArgumentf("index out of range: %d (deck has %d cards)", index, len(d.cards))
+	}
+
+	d.cards = slices.Insert(d.cards, index, cards...)
+	return nil
+}
+
+// Clear removes all cards from the deck, keeping the capacity of the
+// underlying storage so that cards added later do not allocate.
+func (d *Deck) Clear() {
+	d.cards = d.cards[:0]
+}
+
+// Reset restores the deck to a standard 52-card deck in sorted order, as
+// returned by New, reusing the existing storage when it is large enough.
+// The deck always becomes a plain 52-card deck, even if it was created with
+// jokers or from multiple decks.
+//
+// Example:
+//
+//	d := deck.New()
+//	for round := 0; round < rounds; round++ {
+//	    d.Reset()
+//	    d.Shuffle()
+//	    // play the round...
+//	}
+func (d *Deck) Reset() {
+	d.cards = d.cards[:0]
+	for suit := Spades; suit <= Clubs; suit++ {
+		for rank := Ace; rank <= King; rank++ {
+			d.cards = append(d.cards, NewCard(rank, suit))
+		}
+	}
+}
+
+// Cycle moves the top card to the bottom of the deck.
+// It is equivalent to Draw followed by Add, but operates in place.
+// Cycling an empty deck is a no-op.
+func (d *Deck) Cycle() {
+	d.CycleN(1)
+}
+
+// CycleN moves the top n cards to the bottom of the deck, preserving their order.
+// n is taken modulo the deck length, so cycling by Len() leaves the deck unchanged.
+// A negative n cycles in the opposite direction, moving cards from the bottom to the top.
+// Cycling an empty deck is a no-op.
+//
+// Example:
+//
+//	d := deck.New()
+//	d.CycleN(3) // Ace, 2 and 3 of Spades are now at the bottom
+func (d *Deck) CycleN(n int) {
+	if len(d.cards) == 0 {
+		return
+	}
+
+	n %= len(d.cards)
+	if n < 0 {
+		n += len(d.cards)
+	}
+	if n == 0 {
+		return
+	}
+
+	// Rotate left by n in place using three reversals
+	reverseCards(d.cards[:n])
+	reverseCards(d.cards[n:])
+	reverseCards(d.cards)
+}
+
+// Swap exchanges the cards at positions i and j in place.
+// Position 0 is the top of the deck. Swapping a position with itself is a no-op.
+// Returns an error if either position is out of range, leaving the deck unchanged.
+func (d *Deck) Swap(i, j int) error {
+	for _, idx := range [2]int{i, j} {
+		if idx < 0 || idx >= len(d.cards) {
+			return invalidArgumentf("index out of range: %d (deck has %d cards)", idx, len(d.cards))
+		}
+	}
+
+	d.cards[i], d.cards[j] = d.cards[j], d.cards[i]
+	return nil
+}
+
+// reverseCards reverses the order of cards in place.
+func reverseCards(cards []Card) {
+	for i, j := 0, len(cards)-1; i < j; i, j = i+1, j-1 {
+		cards[i], cards[j] = cards[j], cards[i]
+	}
+}
+
+// Sort sorts the deck by suit (Spades, Hearts, Diamonds, Clubs) and then by rank.
+// Jokers are sorted to the end of the deck (Red Joker before Black Joker).
+func (d *Deck) Sort() {
+	sort.Slice(d.cards, func(i, j int) bool {
+		return d.cards[i].Compare(d.cards[j]) < 0
+	})
+}
+
+// SortJokersFirst sorts the deck like Sort, but places the jokers at the top,
+// Red Joker before Black Joker, so that they come before the Ace of Spades.
+// It suits the display of games in which jokers outrank every other card.
+func (d *Deck) SortJokersFirst() {
+	slices.SortFunc(d.cards, func(a, b Card) int {
+		if a.IsJoker() != b.IsJoker() {
+			if a.IsJoker() {
+				return -1
+			}
+			return 1
+		}
+		return a.Compare(b)
+	})
+}
+
+// SuitOrder lists the four suits from first to last for SortBySuitOrder.
+// Sort uses SuitOrder{Spades, Hearts, Diamonds, Clubs}.
+type SuitOrder [4]Suit
+
+// SortBySuitOrder sorts the deck like Sort, but orders the suits according to
+// order, e.g. SuitOrder{Clubs, Diamonds, Hearts, Spades} for bridge.
+// Ranks within a suit stay ascending from Ace to King and jokers are sorted to
+// the end. Returns an error and leaves the deck unchanged if order is not a
+// permutation of the four suits.
+func (d *Deck) SortBySuitOrder(order SuitOrder) error {
+	var position [4]int
+	var seen [4]bool
+	for i, s := range order {
+		if s > Clubs {
+			return fmt.Errorf("invalid suit order: suit %d at index %d is out of range", s, i)
+		}
+		if seen[s] {
+			return fmt.Errorf("invalid suit order: %v at index %d is repeated", s, i)
+		}
+		seen[s] = true
+		position[s] = i
+	}
+
+	sort.Slice(d.cards, func(i, j int) bool {
+		a, b := d.cards[i], d.cards[j]
+		if a.IsJoker() || b.IsJoker() || a.Suit() == b.Suit() {
+			return a.Compare(b) < 0
+		}
+		return position[a.Suit()] < position[b.Suit()]
+	})
+	return nil
+}
+
+// SortAlternatingColors sorts the deck for display so that adjacent cards
+// alternate between black (Spades, Clubs) and red (Hearts, Diamonds) cards
+// wherever possible, as when fanning a hand.
+//
+// The order is fully deterministic: regular cards are split into black and
+// red cards, each sorted as by Sort. The two are then interleaved one card at
+// a time, starting with the color that has more cards, or black if both have
+// the same number. The cards left over from the larger color follow in their
+// sorted order, and jokers come last, red joker first.
+func (d *Deck) SortAlternatingColors() {
+	var black, red, jokers []Card
+	for _, card := range d.cards {
+		switch {
+		case card.IsJoker():
+			jokers = append(jokers, card)
+		case card.Suit() == Hearts || card.Suit() == Diamonds:
+			red = append(red, card)
+		default:
+			black = append(black, card)
+		}
+	}
+	slices.SortFunc(black, Card.Compare)
+	slices.SortFunc(red, Card.Compare)
+	slices.SortFunc(jokers, Card.Compare)
+
+	first, second := black, red
+	if len(red) > len(black) {
+		first, second = red, black
+	}
+
+	sorted := d.cards[:0]
+	for i := range first {
+		sorted = append(sorted, first[i])
+		if i < len(second) {
+			sorted = append(sorted, second[i])
+		}
+	}
+	d.cards = append(sorted, jokers...)
+}
+
+// IsSorted reports whether the deck is already in the order produced by Sort.
+// Empty and single-card decks are sorted.
+func (d *Deck) IsSorted() bool {
+	return slices.IsSortedFunc(d.cards, Card.Compare)
+}
+
+// Cards returns a copy of all cards in the deck.
+// The returned slice is a copy to prevent external modification.
+func (d *Deck) Cards() []Card {
+	cards := make([]Card, len(d.cards))
+	copy(cards, d.cards)
+	return cards
+}
+
+// AppendTo appends the cards in the deck, from top to bottom, to dst and
+// returns the extended slice. Reusing dst across calls avoids the allocation
+// made by Cards when processing many decks.
+//
+// Example:
+//
+//	var buf []deck.Card
+//	for _, d := range decks {
+//	    buf = d.AppendTo(buf[:0])
+//	    analyze(buf)
+//	}
+func (d *Deck) AppendTo(dst []Card) []Card {
+	return append(dst, d.cards...)
+}
+
+// Snapshot is an opaque token recording the order of a deck at a point in time.
+// It is created by Deck.Snapshot and consumed by Deck.Restore.
+type Snapshot struct {
+	deck  *Deck
+	saved []Card
+	buf   []Card
+}
+
+// Snapshot records the current order of the deck so it can later be restored
+// with Restore. The cards are copied once, when the snapshot is taken.
+//
+// The snapshot is tied to the deck it was taken from: after a Restore, the deck
+// uses storage owned by the snapshot, so a snapshot must only be restored into
+// its own deck and must not be used concurrently with it. A snapshot remains
+// valid for as long as the deck is in use and may be restored any number of times.
+//
+// This is intended for simulations that replay from the same shuffled deck
+// millions of times:
+//
+//	d.SecureShuffle()
+//	snap := d.Snapshot()
+//	for i := 0; i < trials; i++ {
+//	    _ = d.Restore(snap)
+//	    hand := d.MustDrawN(5)
+//	    // evaluate hand...
+//	}
+func (d *Deck) Snapshot() *Snapshot {
+	saved := make([]Card, len(d.cards))
+	copy(saved, d.cards)
+	return &Snapshot{
+		deck:  d,
+		saved: saved,
+		buf:   make([]Card, len(d.cards)),
+	}
+}
+
+// Restore resets the deck to the order recorded by snap.
+// It does not allocate, which makes it much cheaper than creating a new deck
+// for each trial. Returns an error if snap was taken from a different deck.
+func (d *Deck) Restore(snap *Snapshot) error {
+	if snap.deck != d {
+		return fmt.Errorf("snapshot was taken from a different deck")
+	}
+
+	copy(snap.buf, snap.saved)
+	d.cards = snap.buf[:len(snap.saved):len(snap.saved)]
+	return nil
+}
+
+// CardSet returns a bitmask with the bit at each card's Ordinal set.
+// Duplicate cards set the same bit, so the mask records which distinct cards
+// are present, not how many of each. Jokers occupy bits 52 and 53, and
+// invalid cards are ignored.
+//
+// Bitmasks make set operations on hands O(1):
+//
+//	both := deck.CardSet(hand1) & deck.CardSet(hand2) // cards in both hands
+//	subset := a&b == a                                // a is a subset of b
+func CardSet(cards []Card) uint64 {
+	var mask uint64
+	for _, card := range cards {
+		if i := card.Ordinal(); i >= 0 {
+			mask |= 1 << i
+		}
+	}
+	return mask
+}
+
+// Bitset returns the bitmask of the distinct cards in the deck, as computed by CardSet.
+// Duplicates in multi-deck setups collapse to a single bit.
+func (d *Deck) Bitset() uint64 {
+	return CardSet(d.cards)
+}
+
+// DeckFromBitset creates a deck containing one card for each bit set in mask,
+// in ordinal order (the order of New followed by the red and black jokers).
+// Bits above 53 do not correspond to any card and are ignored.
+func DeckFromBitset(mask uint64) *Deck {
+	mask &= 1<<54 - 1
+
+	cards := make([]Card, 0, bits.OnesCount64(mask))
+	for mask != 0 {
+		i := bits.TrailingZeros64(mask)
+		card, _ := CardFromOrdinal(i) // i is in range by construction
+		cards = append(cards, card)
+		mask &= mask - 1
+	}
+	return &Deck{cards: cards}
+}
+
+// ToIDs returns the Ordinal of each card from top to bottom, which maps
+// directly onto a protobuf `repeated int32` field. Invalid cards map to -1.
+func (d *Deck) ToIDs() []int32 {
+	ids := make([]int32, len(d.cards))
+	for i, card := range d.cards {
+		ids[i] = int32(card.Ordinal())
+	}
+	return ids
+}
+
+// DeckFromIDs creates a deck from card ordinals as produced by ToIDs, with
+// ids[0] on top. Returns an error identifying the first ID outside the range 0-53.
+func DeckFromIDs(ids []int32) (*Deck, error) {
+	cards := make([]Card, len(ids))
+	for i, id := range ids {
+		card, err := CardFromOrdinal(int(id))
+		if err != nil {
+			return nil, fmt.Errorf("invalid card ID at index %d: %w", i, err)
+		}
+		cards[i] = card
+	}
+	return &Deck{cards: cards}, nil
+}
+
+// Validate checks that every card in the deck is a legal playing card.
+// Regular cards must have a rank from Ace to King, and jokers must use the
+// encodings produced by NewRedJoker and NewBlackJoker.
+// Returns an error identifying the first invalid card, or nil if the deck is valid.
+func (d *Deck) Validate() error {
+	for i, card := range d.cards {
+		if !card.IsValid() {
+			return fmt.Errorf("invalid card at index %d: %#02x", i, byte(card))
+		}
+	}
+	return nil
+}
+
+// String returns a string representation of the deck.
+func (d *Deck) String() string {
+	return d.format(Card.ShortString)
+}
+
+// ColorString returns the same representation as String, with each card
+// rendered by Card.ColorString so that red cards stand out in a terminal.
+// If enabled is false, it returns the same text as String.
+//
+// Example:
+//
+//	fmt.Println(d.ColorString(isTerminal && os.Getenv("NO_COLOR") == ""))
+func (d *Deck) ColorString(enabled bool) string {
+	return d.format(func(c Card) string { return c.ColorString(enabled) })
+}
+
+// format renders the deck for String and ColorString, writing each card with
+// cardString.
+func (d *Deck) format(cardString func(Card) string) string {
+	if d.IsEmpty() {
+		return "Empty Deck"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Deck (%d cards): [", len(d.cards)))
+	for i, card := range d.cards {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(cardString(card))
+	}
+	sb.WriteString("]")
+	return sb.String()
+}
+
+// Filter applies a filter function to create a new deck containing only cards
+// that satisfy the predicate.
+func (d *Deck) Filter(predicate func(Card) bool) *Deck {
+	filtered := make([]Card, 0, len(d.cards))
+	for _, card := range d.cards {
+		if predicate(card) {
+			filtered = append(filtered, card)
+		}
+	}
+	return &Deck{cards: filtered}
+}
+
+// FindFunc returns the first card from the top of the deck that satisfies the
+// predicate, along with its index. Index 0 is the top card, the one Draw would return.
+// If no card matches, it returns -1, the zero Card and false.
+// Unlike Filter, it stops at the first match and does not allocate.
+func (d *Deck) FindFunc(predicate func(Card) bool) (index int, card Card, found bool) {
+	for i, c := range d.cards {
+		if predicate(c) {
+			return i, c, true
+		}
+	}
+	return -1, Card(0), false
+}
+
+// Any reports whether at least one card in the deck satisfies the predicate.
+// It stops at the first match. Returns false for an empty deck.
+func (d *Deck) Any(predicate func(Card) bool) bool {
+	for _, card := range d.cards {
+		if predicate(card) {
+			return true
+		}
+	}
+	return false
+}
+
+// All reports whether every card in the deck satisfies the predicate.
+// It stops at the first card that does not match. Returns true for an empty deck.
+func (d *Deck) All(predicate func(Card) bool) bool {
+	for _, card := range d.cards {
+		if !predicate(card) {
+			return false
+		}
+	}
+	return true
+}
+
+// Reduce folds the cards of the deck from top to bottom into a single value,
+// starting from init and calling f with the accumulated value and each card.
+// Returns init for an empty deck. The deck is not modified.
+//
+// Example:
+//
+//	// Sum of ranks, counting face cards as 10
+//	total := deck.Reduce(d, 0, func(sum int, c deck.Card) int {
+//	    return sum + min(int(c.Rank()), 10)
+//	})
+func Reduce[T any](d *Deck, init T, f func(acc T, c Card) T) T {
+	acc := init
+	for _, card := range d.cards {
+		acc = f(acc, card)
+	}
+	return acc
+}
+
+// ProbabilityNext returns the probability that the next card drawn satisfies
+// the predicate, assuming the remaining cards are in random order.
+// It is the number of matching cards divided by the number of cards in the deck.
+// Returns 0 for an empty deck.
+//
+// Example:
+//
+//	tenValue := func(c deck.Card) bool { return c.Rank() >= deck.Ten && c.Rank() <= deck.King }
+//	p := shoe.ProbabilityNext(tenValue) // 16/52 ≈ 0.31 for a fresh deck
+func (d *Deck) ProbabilityNext(predicate func(Card) bool) float64 {
+	if d.IsEmpty() {
+		return 0
+	}
+	return float64(d.count(predicate)) / float64(len(d.cards))
+}
+
+// ProbabilityInNext returns the probability that at least one of the next n
+// cards drawn satisfies the predicate, assuming the remaining cards are in
+// random order. It follows the hypergeometric distribution: 1 - C(N-K, n) / C(N, n),
+// where N is the number of cards in the deck and K the number of matching cards.
+// n is capped at the number of cards in the deck.
+// Returns 0 for an empty deck or if n < 1.
+func (d *Deck) ProbabilityInNext(n int, predicate func(Card) bool) float64 {
+	if d.IsEmpty() || n < 1 {
+		return 0
+	}
+
+	total := len(d.cards)
+	misses := total - d.count(predicate)
+	n = min(n, total)
+
+	// Probability that none of the next n cards match
+	pNone := 1.0
+	for i := 0; i < n; i++ {
+		if misses-i <= 0 {
+			return 1
+		}
+		pNone *= float64(misses-i) / float64(total-i)
+	}
+	return 1 - pNone
+}
+
+// count returns the number of cards in the deck that satisfy the predicate.
+func (d *Deck) count(predicate func(Card) bool) int {
+	n := 0
+	for _, card := range d.cards {
+		if predicate(card) {
+			n++
+		}
+	}
+	return n
+}
+
+// RemoveSuit removes every card of the given suit from the deck, preserving
+// the order of the remaining cards, and returns the number of cards removed.
+// Jokers do not belong to a suit and are never removed by RemoveSuit.
+func (d *Deck) RemoveSuit(s Suit) int {
+	return d.removeFunc(func(c Card) bool {
+		return !c.IsJoker() && c.Suit() == s
+	})
+}
+
+// RemoveRank removes every card of the given rank from the deck, preserving
+// the order of the remaining cards, and returns the number of cards removed.
+// Passing RedJoker or BlackJoker removes jokers of that color.
+func (d *Deck) RemoveRank(r Rank) int {
+	return d.removeFunc(func(c Card) bool {
+		return c.Rank() == r
+	})
+}
+
+// removeFunc removes in place every card satisfying the predicate and returns
+// the number of cards removed.
+func (d *Deck) removeFunc(predicate func(Card) bool) int {
+	kept := d.cards[:0]
+	for _, card := range d.cards {
+		if !predicate(card) {
+			kept = append(kept, card)
+		}
+	}
+	removed := len(d.cards) - len(kept)
+	d.cards = kept
+	return removed
+}
+
+// SameMultiset reports whether the deck and other contain exactly the same
+// cards with the same number of copies of each, regardless of order.
+// This is useful to check that a sequence of operations neither dropped nor
+// duplicated any card, including in multi-deck setups.
+func (d *Deck) SameMultiset(other *Deck) bool {
+	if len(d.cards) != len(other.cards) {
+		return false
+	}
+
+	var counts [256]int
+	for _, card := range d.cards {
+		counts[card]++
+	}
+	for _, card := range other.cards {
+		counts[card]--
+		if counts[card] < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Duplicates returns the cards that appear more than once in the deck, each
+// listed once in the order in which its second copy is found from the top.
+// It is a diagnostic for catching a card added twice to a single deck.
+// Returns nil if there are no duplicates.
+func (d *Deck) Duplicates() []Card {
+	return d.DuplicatesN(1)
+}
+
+// DuplicatesN is like Duplicates but allows multiplicity copies of each card,
+// as in a shoe built with NewMultiple(multiplicity). A card is reported once,
+// when its copy number multiplicity+1 is found from the top.
+// A multiplicity of 0 or less reports every distinct card in the deck.
+//
+// Example:
+//
+//	shoe, _ := deck.NewMultiple(6)
+//	if dups := shoe.DuplicatesN(6); dups != nil {
+//	    log.Printf("shoe has extra copies of %v", dups)
+//	}
+func (d *Deck) DuplicatesN(multiplicity int) []Card {
+	var counts [256]int
+	var dups []Card
+	for _, card := range d.cards {
+		counts[card]++
+		if counts[card] == max(multiplicity, 0)+1 {
+			dups = append(dups, card)
+		}
+	}
+	return dups
+}
+
+// RemoveCards removes the given cards from the deck, preserving the order of
+// the remaining cards. Each entry in cards removes one matching card, so a
+// card listed twice must be present twice (e.g. in a multi-deck shoe).
+// If any card is not in the deck, the deck remains unchanged and an error
+// naming the missing card is returned.
+//
+// This is useful for Monte Carlo simulations where known cards (hole cards,
+// board) are stripped before shuffling and dealing the remaining stub.
+//
+// Example:
+//
+//	d := deck.New()
+//	known := []deck.Card{deck.NewCard(deck.Ace, deck.Spades), deck.NewCard(deck.King, deck.Spades)}
+//	if err := d.RemoveCards(known); err != nil {
+//	    log.Fatal(err)
+//	}
+//	// deck now has 50 cards
+func (d *Deck) RemoveCards(cards []Card) error {
+	var available [256]int
+	for _, card := range d.cards {
+		available[card]++
+	}
+
+	var remove [256]int
+	for _, card := range cards {
+		remove[card]++
+		if remove[card] > available[card] {
+			return fmt.Errorf("card not in deck: %s", card)
+		}
+	}
+
+	kept := d.cards[:0]
+	for _, card := range d.cards {
+		if remove[card] > 0 {
+			remove[card]--
+			continue
+		}
+		kept = append(kept, card)
+	}
+	d.cards = kept
+
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// This provides efficient binary encoding for network transfer.
+// Format: 4 bytes for length (little-endian uint32) + 1 byte per card.
+// Use MarshalBinaryBigEndian for clients that expect network byte order.
+func (d *Deck) MarshalBinary() ([]byte, error) {
+	return d.marshalBinary(binary.LittleEndian), nil
+}
+
+// MarshalBinaryBigEndian encodes the deck like MarshalBinary but writes the
+// length prefix in big-endian (network byte order), which is the default in
+// many non-Go clients. The card bytes are identical in both formats.
+// Format: 4 bytes for length (big-endian uint32) + 1 byte per card.
+//
+// Example: a deck holding the Ace and 2 of Spades encodes as
+//
+//	00 00 00 02 01 02 // MarshalBinaryBigEndian
+//	02 00 00 00 01 02 // MarshalBinary
+func (d *Deck) MarshalBinaryBigEndian() ([]byte, error) {
+	return d.marshalBinary(binary.BigEndian), nil
+}
+
+// marshalBinary encodes the deck with its length prefix in the given byte order.
+func (d *Deck) marshalBinary(order binary.ByteOrder) []byte {
+	// 4 bytes for length + 1 byte per card
+	data := make([]byte, 4+len(d.cards))
+	order.PutUint32(data[0:4], uint32(len(d.cards)))
+	for i, card := range d.cards {
+		data[4+i] = byte(card)
+	}
+	return data
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// This decodes the binary format produced by MarshalBinary.
+// Returns an error identifying the first byte that is not a valid card.
+// If decoding fails, the deck remains unchanged.
+func (d *Deck) UnmarshalBinary(data []byte) error {
+	return d.unmarshalBinary(data, binary.LittleEndian)
+}
+
+// UnmarshalBinaryBigEndian decodes the format produced by
+// MarshalBinaryBigEndian, whose length prefix is big-endian.
+// Returns an error identifying the first byte that is not a valid card.
+// If decoding fails, the deck remains unchanged.
+func (d *Deck) UnmarshalBinaryBigEndian(data []byte) error {
+	return d.unmarshalBinary(data, binary.BigEndian)
+}
+
+// unmarshalBinary decodes a deck whose length prefix is in the given byte order.
+func (d *Deck) unmarshalBinary(data []byte, order binary.ByteOrder) error {
+	if len(data) < 4 {
+		return fmt.Errorf("invalid data: too short")
+	}
+
+	count := order.Uint32(data[0:4])
+	if len(data) != int(4+count) {
+		return fmt.Errorf("invalid data: expected %d bytes, got %d", 4+count, len(data))
+	}
+
+	cards := make([]Card, count)
+	for i := uint32(0); i < count; i++ {
+		card := Card(data[4+i])
+		if !card.IsValid() {
+			return fmt.Errorf("invalid data: invalid card at index %d: %#02x", i, data[4+i])
+		}
+		cards[i] = card
+	}
+
+	d.cards = cards
+	return nil
+}
+
+// streamChunkSize is the size of the buffer used by WriteTo and ReadFrom.
+const streamChunkSize = 512
+
+// WriteTo implements io.WriterTo.
+// It writes the deck in the MarshalBinary format directly to w without
+// building the whole encoding in memory: the length header and the cards are
+// written in chunks of at most 512 bytes, so peak memory stays constant however
+// large the shoe is. Returns the number of bytes written.
+func (d *Deck) WriteTo(w io.Writer) (int64, error) {
+	var buf [streamChunkSize]byte
+	binary.LittleEndian.PutUint32(buf[0:4], uint32(len(d.cards)))
+
+	var written int64
+	n := 4
+	for _, card := range d.cards {
+		if n == len(buf) {
+			m, err := w.Write(buf[:n])
+			written += int64(m)
+			if err != nil {
+				return written, err
+			}
+			n = 0
+		}
+		buf[n] = byte(card)
+		n++
+	}
+
+	m, err := w.Write(buf[:n])
+	written += int64(m)
+	return written, err
+}
+
+// ReadFrom implements io.ReaderFrom.
+// It reads a single deck in the MarshalBinary format from r, consuming exactly
+// the bytes of that deck, so several decks can be read from the same stream.
+// If reading fails, the deck remains unchanged and an error is returned.
+// Returns the number of bytes read.
+func (d *Deck) ReadFrom(r io.Reader) (int64, error) {
+	var buf [streamChunkSize]byte
+
+	n, err := io.ReadFull(r, buf[:4])
+	read := int64(n)
+	if err != nil {
+		return read, fmt.Errorf("invalid data: reading header: %w", err)
+	}
+	count := int(binary.LittleEndian.Uint32(buf[0:4]))
+
+	// Grow the slice as data arrives rather than trusting the header up front
+	cards := make([]Card, 0, min(count, streamChunkSize))
+	for len(cards) < count {
+		chunk := buf[:min(count-len(cards), len(buf))]
+		n, err := io.ReadFull(r, chunk)
+		read += int64(n)
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return read, fmt.Errorf("invalid data: expected %d cards, got %d: %w", count, len(cards)+n, err)
+		}
+		for _, b := range chunk {
+			card := Card(b)
+			if !card.IsValid() {
+				return read, fmt.Errorf("invalid data: invalid card at index %d: %#02x", len(cards), b)
+			}
+			cards = append(cards, card)
+		}
+	}
+
+	d.cards = cards
+	return read, nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// This provides a human-readable format for saved games that diffs well in
+// version control. Format: the ShortString of each card from top to bottom,
+// separated by single spaces (e.g. "Ace♠ 10♦ JKR"). An empty deck encodes as empty text.
+func (d *Deck) MarshalText() ([]byte, error) {
+	var sb strings.Builder
+	for i, card := range d.cards {
+		if !card.IsValid() {
+			return nil, fmt.Errorf("invalid card at index %d: %#02x", i, byte(card))
+		}
+		if i > 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(card.ShortString())
+	}
+	return []byte(sb.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// This decodes the format produced by MarshalText, accepting any whitespace
+// between cards. If decoding fails, the deck remains unchanged and an error
+// identifying the first invalid token is returned.
+func (d *Deck) UnmarshalText(text []byte) error {
+	tokens := strings.Fields(string(text))
+
+	cards := make([]Card, len(tokens))
+	for i, token := range tokens {
+		card, err := ParseCard(token)
+		if err != nil {
+			return fmt.Errorf("invalid data: card %d: %w", i, err)
+		}
+		cards[i] = card
+	}
+
+	d.cards = cards
+	return nil
+}
+
+// Size returns the byte size of the deck when marshaled.
+// This is useful for network transfer size estimation.
+func (d *Deck) Size() int {
+	return 4 + len(d.cards) // 4 bytes header + 1 byte per card
+}
+
+// MarshalDecks encodes multiple decks into a single binary stream.
+// Format: 4 bytes for the number of decks (uint32), followed by each deck
+// in the MarshalBinary format (4-byte length + 1 byte per card).
+// Returns an error if any deck is nil.
+func MarshalDecks(decks []*Deck) ([]byte, error) {
+	size := 4
+	for i, d := range decks {
+		if d == nil {
+			return nil, fmt.Errorf("deck at index %d is nil", i)
+		}
+		size += d.Size()
+	}
+
+	data := make([]byte, 4, size)
+	binary.LittleEndian.PutUint32(data[0:4], uint32(len(decks)))
+	for _, d := range decks {
+		data = binary.LittleEndian.AppendUint32(data, uint32(len(d.cards)))
+		for _, card := range d.cards {
+			data = append(data, byte(card))
+		}
+	}
+	return data, nil
+}
+
+// UnmarshalDecks decodes a binary stream produced by MarshalDecks.
+// Returns an error if the stream is truncated or has trailing bytes.
+func UnmarshalDecks(data []byte) ([]*Deck, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("invalid data: too short")
+	}
+
+	count := binary.LittleEndian.Uint32(data[0:4])
+	// Every deck needs at least its 4-byte header, which bounds the allocation below
+	if uint64(count)*4 > uint64(len(data)-4) {
+		return nil, fmt.Errorf("invalid data: truncated, expected %d decks", count)
+	}
+
+	decks := make([]*Deck, count)
+	offset := 4
+	for i := range decks {
+		if len(data)-offset < 4 {
+			return nil, fmt.Errorf("invalid data: truncated header for deck %d", i)
+		}
+		n := int(binary.LittleEndian.Uint32(data[offset : offset+4]))
+		offset += 4
+
+		if len(data)-offset < n {
+			return nil, fmt.Errorf("invalid data: truncated deck %d: expected %d cards, got %d", i, n, len(data)-offset)
+		}
+		d := &Deck{cards: make([]Card, n)}
+		for j := range d.cards {
+			card := Card(data[offset+j])
+			if !card.IsValid() {
+				return nil, fmt.Errorf("invalid data: deck %d: invalid card at index %d: %#02x", i, j, data[offset+j])
+			}
+			d.cards[j] = card
+		}
+		offset += n
+		decks[i] = d
+	}
+
+	if offset != len(data) {
+		return nil, fmt.Errorf("invalid data: %d trailing bytes", len(data)-offset)
+	}
+	return decks, nil
+}
+
+// Fingerprint returns a stable, order-sensitive 64-bit FNV-1a hash of the cards in the deck.
+// It is a cheap way to check that a deck received over the network matches the sender's.
+// For protection against deliberate tampering, use Checksum instead.
+func (d *Deck) Fingerprint() uint64 {
+	h := fnv.New64a()
+	for _, card := range d.cards {
+		_, _ = h.Write([]byte{byte(card)}) // [hash.Hash.Write] never returns an error
+	}
+	return h.Sum64()
+}
+
+// Checksum returns the SHA-256 digest of the cards in the deck, in order.
+// Unlike Fingerprint, it is collision resistant and suitable for detecting tampering.
+// The digest covers only the card bytes, independent of the MarshalBinary length header.
+func (d *Deck) Checksum() [32]byte {
+	data := make([]byte, len(d.cards))
+	for i, card := range d.cards {
+		data[i] = byte(card)
+	}
+	return sha256.Sum256(data)
+}
+
+const (
+	// defaultPenetration is the fraction of a shoe dealt before the cut card
+	// is reached, typical for casino blackjack.
+	defaultPenetration = 0.75
+)
+
+// Shoe models a casino dealing shoe holding several standard decks shuffled
+// together, with a cut card placed at a configurable penetration. Once the
+// cut card is reached, NeedsReshuffle reports true; dealing may continue to
+// finish the current round, after which the shoe should be reshuffled.
+type Shoe struct {
+	deck        *Deck
+	numDecks    int
+	penetration float64
+	dealt       int
+}
+
+// NewShoe creates a shoe of numDecks standard 52-card decks, securely shuffled,
+// with the cut card at the default penetration of 75%.
+// Returns an error if numDecks is less than 1.
+//
+// Example:
+//
+//	shoe, err := deck.NewShoe(6)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for !shoe.NeedsReshuffle() {
+//	    card, _ := shoe.Draw()
+//	    // play a round...
+//	}
+//	shoe.Reshuffle()
+func NewShoe(numDecks int) (*Shoe, error) {
+	d, err := NewMultiple(numDecks)
+	if err != nil {
+		return nil, err
+	}
+	d.SecureShuffle()
+
+	return &Shoe{
+		deck:        d,
+		numDecks:    numDecks,
+		penetration: defaultPenetration,
+	}, nil
+}
+
+// SetPenetration places the cut card so that NeedsReshuffle reports true
+// after the given fraction of the shoe has been dealt.
+// Returns an error if penetration is not in the range (0, 1].
+func (s *Shoe) SetPenetration(penetration float64) error {
+	if !(penetration > 0 && penetration <= 1) {
+		return fmt.Errorf("penetration must be in the range (0, 1], got %v", penetration)
+	}
+	s.penetration = penetration
+	return nil
+}
+
+// Penetration returns the fraction of the shoe dealt before the cut card is reached.
+func (s *Shoe) Penetration() float64 {
+	return s.penetration
+}
+
+// Len returns the number of cards remaining in the shoe.
+func (s *Shoe) Len() int {
+	return s.deck.Len()
+}
+
+// Dealt returns the number of cards dealt since the last reshuffle.
+func (s *Shoe) Dealt() int {
+	return s.dealt
+}
+
+// Draw removes and returns the top card from the shoe.
+// Drawing past the cut card is allowed so that a round can be finished.
+// Returns an error if the shoe is empty.
+func (s *Shoe) Draw() (Card, error) {
+	card, err := s.deck.Draw()
+	if err != nil {
+		return Card(0), insufficientCardsf("cannot draw from empty shoe")
+	}
+	s.dealt++
+	return card, nil
+}
+
+// NeedsReshuffle returns true once the cut card has been reached, i.e. when
+// the fraction of the shoe dealt since the last reshuffle is at least the penetration.
+func (s *Shoe) NeedsReshuffle() bool {
+	return s.dealt >= s.cutCard()
+}
+
+// cutCard returns the number of cards dealt at which the cut card is reached.
+func (s *Shoe) cutCard() int {
+	total := s.numDecks * 52
+	return int(math.Ceil(s.penetration * float64(total)))
+}
+
+// Reshuffle gathers all cards back into the shoe and securely shuffles them.
+func (s *Shoe) Reshuffle() {
+	s.ReshuffleWith(SecureShuffler{})
+}
+
+// ReshuffleWith gathers all cards back into the shoe and shuffles them using
+// a custom Shuffler, e.g. a seeded one for reproducible simulations.
+func (s *Shoe) ReshuffleWith(shuffler Shuffler) {
+	s.deck, _ = NewMultiple(s.numDecks) // numDecks was validated by NewShoe
+	s.deck.ShuffleWith(shuffler)
+	s.dealt = 0
+}
+
+// Table tracks the dealer button for a game played over many hands. Each
+// Deal starts with the seat to the left of the dealer and then passes the
+// button one seat to the left, so callers do not need to maintain the
+// rotation themselves. Seats are numbered 0 to numPlayers-1 clockwise.
+type Table struct {
+	deck       *Deck
+	numPlayers int
+	dealer     int
+}
+
+// NewTable creates a table of numPlayers seats that deals from d, with seat 0
+// holding the dealer button. The table uses d directly, so shuffling or
+// refilling d between hands affects the next deal.
+// Returns an error if numPlayers is less than 1.
+//
+// Example:
+//
+//	d := deck.New()
+//	table, err := deck.NewTable(d, 4)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for round := 0; round < rounds; round++ {
+//	    d.Reset()
+//	    d.SecureShuffle()
+//	    hands, _ := table.Deal(13)
+//	    // play the round...
+//	}
+func NewTable(d *Deck, numPlayers int) (*Table, error) {
+	if numPlayers < 1 {
+		return nil, invalidArgumentf("number of players must be at least 1")
+	}
+	return &Table{deck: d, numPlayers: numPlayers}, nil
+}
+
+// Deck returns the deck the table deals from.
+func (t *Table) Deck() *Deck {
+	return t.deck
+}
+
+// Dealer returns the seat holding the dealer button.
+func (t *Table) Dealer() int {
+	return t.dealer
+}
+
+// SetDealer moves the dealer button to seat, e.g. after cutting for the deal.
+// Returns an error if seat is out of range, leaving the button in place.
+func (t *Table) SetDealer(seat int) error {
+	if seat < 0 || seat >= t.numPlayers {
+		return invalidArgumentf("dealer seat must be between 0 and %d, got %d", t.numPlayers-1, seat)
+	}
+	t.dealer = seat
+	return nil
+}
+
+// Deal deals cardsEach cards to every seat one card at a time, round-robin,
+// starting to the left of the dealer, and then passes the dealer button to
+// that seat. The returned hands are indexed by seat, as with Deck.DealFrom.
+// If the deal fails, the deck and the button remain unchanged and an error
+// is returned.
+func (t *Table) Deal(cardsEach int) ([][]Card, error) {
+	next := (t.dealer + 1) % t.numPlayers
+	hands, err := t.deck.DealFrom(next, t.numPlayers, cardsEach)
+	if err != nil {
+		return nil, err
+	}
+	t.dealer = next
+	return hands, nil
+}
+
+// CommunityDealer deals the community cards of Texas Hold'em from a deck one
+// street at a time, as a live game does: Flop, then Turn, then River, each
+// after burning one card. Unlike DealHoldem, the streets can be dealt as the
+// betting progresses, and the order of the streets is enforced.
+type CommunityDealer struct {
+	deck  *Deck
+	board []Card
+}
+
+// NewCommunityDealer creates a CommunityDealer that deals from d, which
+// should already hold the deck left after the hole cards were dealt.
+//
+// Example:
+//
+//	holes, _ := d.Deal(6, 2)
+//	board := deck.NewCommunityDealer(d)
+//	flop, err := board.Flop()
+//	// betting round...
+//	turn, err := board.Turn()
+//	// betting round...
+//	river, err := board.River()
+func NewCommunityDealer(d *Deck) *CommunityDealer {
+	return &CommunityDealer{deck: d}
+}
+
+// Flop burns one card and deals the three-card flop.
+// Returns an error if the flop has already been dealt or the deck holds fewer
+// than 4 cards, in which case the deck remains unchanged.
+func (c *CommunityDealer) Flop() ([]Card, error) {
+	if len(c.board) != 0 {
+		return nil, fmt.Errorf("flop has already been dealt")
+	}
+	return c.street(3)
+}
+
+// Turn burns one card and deals the turn.
+// Returns an error if the flop has not been dealt, the turn has already been
+// dealt, or the deck holds fewer than 2 cards, in which case the deck remains
+// unchanged.
+func (c *CommunityDealer) Turn() (Card, error) {
+	switch {
+	case len(c.board) < 3:
+		return Card(0), fmt.Errorf("cannot deal the turn before the flop")
+	case len(c.board) > 3:
+		return Card(0), fmt.Errorf("turn has already been dealt")
+	}
+	drawn, err := c.street(1)
+	if err != nil {
+		return Card(0), err
+	}
+	return drawn[0], nil
+}
+
+// River burns one card and deals the river.
+// Returns an error if the turn has not been dealt, the river has already been
+// dealt, or the deck holds fewer than 2 cards, in which case the deck remains
+// unchanged.
+func (c *CommunityDealer) River() (Card, error) {
+	switch {
+	case len(c.board) < 4:
+		return Card(0), fmt.Errorf("cannot deal the river before the turn")
+	case len(c.board) > 4:
+		return Card(0), fmt.Errorf("river has already been dealt")
+	}
+	drawn, err := c.street(1)
+	if err != nil {
+		return Card(0), err
+	}
+	return drawn[0], nil
+}
+
+// Board returns a copy of the community cards dealt so far, in the order
+// they were dealt.
+func (c *CommunityDealer) Board() []Card {
+	return slices.Clone(c.board)
+}
+
+// street burns one card, draws n cards and adds them to the board.
+func (c *CommunityDealer) street(n int) ([]Card, error) {
+	_, drawn, err := c.deck.BurnAndDraw(1, n)
+	if err != nil {
+		return nil, err
+	}
+	c.board = append(c.board, drawn...)
+	return drawn, nil
+}
+
+// AnnotatedCard pairs a card with a game-specific value.
+type AnnotatedCard[T any] struct {
+	Card  Card
+	Value T
+}
+
+// AnnotatedDeck is a deck in which every position carries a value of type T,
+// such as a power or cost in a deck-building game. Unlike a map keyed by Card,
+// duplicate cards can carry different values. Values move together with
+// their cards when the deck is shuffled, drawn from or dealt.
+type AnnotatedDeck[T any] struct {
+	deck   *Deck
+	values []T
+}
+
+// NewAnnotatedDeck creates an annotated deck where values[i] belongs to cards[i].
+// Both slices are copied. Returns an error if the slices differ in length.
+//
+// Example:
+//
+//	d := deck.New()
+//	costs := make([]int, d.Len())
+//	for i, c := range d.Cards() {
+//	    costs[i] = int(c.Rank())
+//	}
+//	ad, err := deck.NewAnnotatedDeck(d.Cards(), costs)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	ad.SecureShuffle()
+//	card, cost, _ := ad.Draw()
+func NewAnnotatedDeck[T any](cards []Card, values []T) (*AnnotatedDeck[T], error) {
+	if len(cards) != len(values) {
+		return nil, fmt.Errorf("cards and values must have the same length: %d and %d", len(cards), len(values))
+	}
+
+	return &AnnotatedDeck[T]{
+		deck:   &Deck{cards: slices.Clone(cards)},
+		values: slices.Clone(values),
+	}, nil
+}
+
+// Len returns the number of cards currently in the deck.
+func (a *AnnotatedDeck[T]) Len() int {
+	return a.deck.Len()
+}
+
+// Add adds a card with its value to the bottom of the deck.
+func (a *AnnotatedDeck[T]) Add(card Card, value T) {
+	a.deck.Add(card)
+	a.values = append(a.values, value)
+}
+
+// Cards returns a copy of the cards in the deck.
+func (a *AnnotatedDeck[T]) Cards() []Card {
+	return a.deck.Cards()
+}
+
+// Values returns a copy of the values in the deck, aligned with Cards.
+func (a *AnnotatedDeck[T]) Values() []T {
+	return slices.Clone(a.values)
+}
+
+// Draw removes and returns the top card from the deck together with its value.
+// Returns an error if the deck is empty.
+func (a *AnnotatedDeck[T]) Draw() (Card, T, error) {
+	card, err := a.deck.Draw()
+	if err != nil {
+		var zero T
+		return card, zero, err
+	}
+
+	value := a.values[0]
+	a.values = a.values[1:]
+	return card, value, nil
+}
+
+// Deal distributes cards and their values to numPlayers players, cardsEach
+// cards per player, in sequential blocks like Deck.Deal.
+// Returns an error and leaves the deck unchanged if the parameters are
+// invalid or there are not enough cards.
+func (a *AnnotatedDeck[T]) Deal(numPlayers, cardsEach int) ([][]AnnotatedCard[T], error) {
+	if err := a.deck.validateDeal(numPlayers, cardsEach, 0); err != nil {
+		return nil, err
+	}
+
+	hands := make([][]AnnotatedCard[T], numPlayers)
+	for p := range hands {
+		hand := make([]AnnotatedCard[T], cardsEach)
+		for i := range hand {
+			k := p*cardsEach + i
+			hand[i] = AnnotatedCard[T]{Card: a.deck.cards[k], Value: a.values[k]}
+		}
+		hands[p] = hand
+	}
+
+	totalCards := numPlayers * cardsEach
+	a.deck.cards = a.deck.cards[totalCards:]
+	a.values = a.values[totalCards:]
+
+	return hands, nil
+}
+
+// SecureShuffle randomizes the order of cards and their values using crypto/rand.
+func (a *AnnotatedDeck[T]) SecureShuffle() {
+	a.ShuffleWith(SecureShuffler{})
+}
+
+// ShuffleWith randomizes the order of cards and their values using a custom Shuffler.
+func (a *AnnotatedDeck[T]) ShuffleWith(shuffler Shuffler) {
+	cards := a.deck.cards
+	shuffler.Shuffle(len(cards), func(i, j int) {
+		cards[i], cards[j] = cards[j], cards[i]
+		a.values[i], a.values[j] = a.values[j], a.values[i]
+	})
 }
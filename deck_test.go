@@ -1,7 +1,15 @@
 package deck
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"math"
+	mathrand "math/rand"
+	randv2 "math/rand/v2"
+	"slices"
 	"testing"
 )
 
@@ -238,6 +246,22 @@ func TestDeckShuffle(t *testing.T) {
 	}
 }
 
+func TestDeckShuffleTightLoop(t *testing.T) {
+	// Decks shuffled back to back, possibly within the same clock tick,
+	// must not share an order.
+	const n = 1000
+	seen := make(map[uint64]bool, n)
+	for i := 0; i < n; i++ {
+		d := New()
+		d.Shuffle()
+		fp := d.Fingerprint()
+		if seen[fp] {
+			t.Fatalf("Shuffle() #%d repeated an earlier order", i)
+		}
+		seen[fp] = true
+	}
+}
+
 func TestDeckShuffleWithSeed(t *testing.T) {
 	d1 := New()
 	d2 := New()
@@ -668,6 +692,63 @@ func TestDeckUnmarshalBinaryErrors(t *testing.T) {
 	}
 }
 
+func TestDeckMarshalBinaryBigEndian(t *testing.T) {
+	d := NewFromCards([]Card{NewCard(Ace, Spades), NewCard(Two, Spades)})
+
+	big, err := d.MarshalBinaryBigEndian()
+	if err != nil {
+		t.Fatalf("MarshalBinaryBigEndian() got error: %v, want nil", err)
+	}
+	if want := []byte{0x00, 0x00, 0x00, 0x02, 0x01, 0x02}; !bytes.Equal(big, want) {
+		t.Errorf("MarshalBinaryBigEndian() = % x, want % x", big, want)
+	}
+
+	// The little-endian format is unchanged
+	little, _ := d.MarshalBinary()
+	if want := []byte{0x02, 0x00, 0x00, 0x00, 0x01, 0x02}; !bytes.Equal(little, want) {
+		t.Errorf("MarshalBinary() = % x, want % x", little, want)
+	}
+
+	full := New()
+	full.ShuffleWithSeed(11)
+	data, _ := full.MarshalBinaryBigEndian()
+	d2 := &Deck{}
+	if err := d2.UnmarshalBinaryBigEndian(data); err != nil {
+		t.Fatalf("UnmarshalBinaryBigEndian() got error: %v, want nil", err)
+	}
+	if !slices.Equal(d2.Cards(), full.Cards()) {
+		t.Errorf("After UnmarshalBinaryBigEndian(), deck = %v, want %v", d2, full)
+	}
+}
+
+func TestDeckUnmarshalBinaryBigEndianErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr string
+	}{
+		{"too short", []byte{0x00}, "invalid data: too short"},
+		{"little-endian header", []byte{0x01, 0x00, 0x00, 0x00, 0x01}, "invalid data: expected 16777220 bytes, got 5"},
+		{"invalid card", []byte{0x00, 0x00, 0x00, 0x01, 0x00}, "invalid data: invalid card at index 0: 0x00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := New()
+			err := d.UnmarshalBinaryBigEndian(tt.data)
+			if err == nil {
+				t.Fatalf("UnmarshalBinaryBigEndian(% x) got nil error, want %q", tt.data, tt.wantErr)
+			}
+			if got, want := err.Error(), tt.wantErr; got != want {
+				t.Errorf("UnmarshalBinaryBigEndian(% x) error = %q, want %q", tt.data, got, want)
+			}
+			if got, want := d.Len(), 52; got != want {
+				t.Errorf("After failed UnmarshalBinaryBigEndian, deck.Len() = %d, want %d", got, want)
+			}
+		})
+	}
+}
+
 func TestDeckSize(t *testing.T) {
 	tests := []struct {
 		name string
@@ -727,6 +808,35 @@ func BenchmarkFilter(b *testing.B) {
 	}
 }
 
+func TestSecureShufflerUniform(t *testing.T) {
+	// SecureShuffler cannot be seeded, so the bounds allow for chance: with 49
+	// degrees of freedom they fail a uniform shuffler about once in 10^4 runs,
+	// while a biased one scores far above them
+	if got := ShuffleUniformityTest(SecureShuffler{}, 8, 40000); got < 0.4 || got > 2 {
+		t.Errorf("ShuffleUniformityTest(SecureShuffler) = %.3f, want about 1", got)
+	}
+}
+
+func TestSecureRandRefill(t *testing.T) {
+	var r secureRand
+	seen := make(map[uint64]bool)
+	// Three buffers' worth of values, crossing two refills
+	for i := 0; i < 3*secureRandBufferSize/8; i++ {
+		seen[r.next()] = true
+	}
+	if got, want := len(seen), 3*secureRandBufferSize/8; got != want {
+		t.Errorf("secureRand returned %d distinct values out of %d, want all distinct", got, want)
+	}
+
+	// Shuffling more cards than one buffer covers still permutes the deck
+	d, _ := NewMultiple(4)
+	d.SecureShuffle()
+	want, _ := NewMultiple(4)
+	if !d.SameMultiset(want) {
+		t.Error("SecureShuffle() of a 208-card deck changed its cards")
+	}
+}
+
 func BenchmarkSecureShuffle(b *testing.B) {
 	d := New()
 	for b.Loop() {
@@ -1096,7 +1206,7 @@ func TestDealValidation(t *testing.T) {
 			numPlayers:     1,
 			cardsPerPlayer: 53,
 			deckSize:       52,
-			wantErr:        "cards per player exceeds maximum of 52",
+			wantErr:        "insufficient cards: need 53, have 52",
 		},
 		{
 			name:           "insufficient cards in deck",
@@ -1336,6 +1446,73 @@ func TestDealIndependentHands(t *testing.T) {
 	}
 }
 
+func TestDealtHandsAreIsolated(t *testing.T) {
+	tests := []struct {
+		name string
+		deal func(d *Deck) [][]Card
+	}{
+		{"Deal", func(d *Deck) [][]Card { h, _ := d.Deal(4, 5); return h }},
+		{"DealFrom", func(d *Deck) [][]Card { h, _ := d.DealFrom(2, 4, 5); return h }},
+		{"DealFunc", func(d *Deck) [][]Card { h, _ := d.DealFunc(4, 5, nil); return h }},
+		{"DealAll", func(d *Deck) [][]Card { h, _ := d.DealAll(3); return h }},
+		{"DealHands", func(d *Deck) [][]Card { h, _ := d.DealHands([]int{2, 5, 3}); return h }},
+		{"DealNoJokers", func(d *Deck) [][]Card { h, _ := d.DealNoJokers(4, 5); return h }},
+		{"DealMatching", func(d *Deck) [][]Card {
+			h, _, _ := d.DealMatching(3, 4, func(c Card) bool { return c.Rank() > Five })
+			return h
+		}},
+		{"DealTracked", func(d *Deck) [][]Card { h, _, _ := d.DealTracked(4, 5); return h }},
+		{"DealSkipping", func(d *Deck) [][]Card { h, _ := d.DealSkipping(5, []bool{true, true, true}); return h }},
+		{"DealRounds", func(d *Deck) [][]Card { h, _ := d.DealRounds(3, []int{3, 2}); return h }},
+		{"DealUnique", func(d *Deck) [][]Card { h, _ := d.DealUnique(3, 5); return h }},
+		{"ShuffleAndDeal", func(d *Deck) [][]Card { h, _ := d.ShuffleAndDeal(1, 4, 5); return h }},
+		{"DealSorted", func(d *Deck) [][]Card { h, _ := d.DealSorted(4, 5); return h }},
+		{"DealWithVisibility", func(d *Deck) [][]Card { h, _, _ := d.DealWithVisibility(4, make([]bool, 5)); return h }},
+		{"DealWithKitty", func(d *Deck) [][]Card { h, k, _ := d.DealWithKitty(4, 5, 3); return append(h, k) }},
+		{"SplitDeal", func(d *Deck) [][]Card { h, _, _ := d.SplitDeal(4, 5); return h }},
+		{"DealHoldem", func(d *Deck) [][]Card {
+			h, flop, turn, river, _ := d.DealHoldem(3)
+			return append(h, flop, turn, river)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := New()
+			hands := tt.deal(d)
+			deckBefore := d.Cards()
+			handsBefore := make([][]Card, len(hands))
+			for i, hand := range hands {
+				handsBefore[i] = slices.Clone(hand)
+				if got, want := cap(hand), len(hand); got != want {
+					t.Errorf("%s hand %d has cap %d, want %d", tt.name, i, got, want)
+				}
+			}
+
+			for i := range hands {
+				// Overwriting and appending to one hand must leave everything else intact
+				for k := range hands[i] {
+					hands[i][k] = NewRedJoker()
+				}
+				handsBefore[i] = slices.Clone(hands[i])
+				grown := append(hands[i], NewRedJoker(), NewBlackJoker())
+				for k := range grown {
+					grown[k] = NewBlackJoker()
+				}
+
+				for j, hand := range hands {
+					if j != i && !slices.Equal(hand, handsBefore[j]) {
+						t.Errorf("%s: writing to hand %d changed hand %d to %v, want %v", tt.name, i, j, hand, handsBefore[j])
+					}
+				}
+				if !slices.Equal(d.Cards(), deckBefore) {
+					t.Errorf("%s: writing to hand %d changed the deck", tt.name, i)
+				}
+			}
+		})
+	}
+}
+
 func BenchmarkDeal(b *testing.B) {
 	tests := []struct {
 		name           string
@@ -1482,7 +1659,7 @@ func TestMustDeal_Panics(t *testing.T) {
 		{"negative players", -1, 5, 52, "number of players must be at least 1"},
 		{"zero cards", 4, 0, 52, "cards per player must be at least 1"},
 		{"negative cards", 4, -1, 52, "cards per player must be at least 1"},
-		{"too many cards per player", 1, 53, 52, "cards per player exceeds maximum of 52"},
+		{"too many cards per player", 1, 53, 52, "insufficient cards: need 53, have 52"},
 		{"insufficient cards", 4, 5, 15, "insufficient cards: need 20, have 15"},
 		{"insufficient cards (too many players)", 53, 1, 52, "insufficient cards: need 53, have 52"},
 	}
@@ -1592,7 +1769,7 @@ func TestDealHands_Validation(t *testing.T) {
 			name:        "hand too large",
 			handSizes:   []int{53},
 			deckSize:    52,
-			expectedErr: "hand size (53) at index 0 exceeds maximum of 52",
+			expectedErr: "hand size (53) at index 0 exceeds deck size of 52",
 		},
 		{
 			name:        "insufficient cards",
@@ -1788,7 +1965,7 @@ func TestMustDealHands_Panics(t *testing.T) {
 		{"empty slice", []int{}, 52, "handSizes must contain at least one hand"},
 		{"zero value", []int{2, 0, 3}, 52, "hand size must be positive: got 0 at index 1"},
 		{"negative value", []int{2, -1, 3}, 52, "hand size must be positive: got -1 at index 1"},
-		{"hand too large", []int{53}, 52, "hand size (53) at index 0 exceeds maximum of 52"},
+		{"hand too large", []int{53}, 52, "hand size (53) at index 0 exceeds deck size of 52"},
 		{"insufficient cards", []int{5, 5, 5, 5}, 15, "insufficient cards: need 20, have 15"},
 	}
 
@@ -1812,3 +1989,5979 @@ func TestMustDealHands_Panics(t *testing.T) {
 		})
 	}
 }
+
+func TestDeckCycle(t *testing.T) {
+	d := New()
+	originalCards := d.Cards()
+
+	d.Cycle()
+
+	if got, want := d.Len(), 52; got != want {
+		t.Errorf("After Cycle(), deck.Len() = %d, want %d", got, want)
+	}
+
+	cards := d.Cards()
+	if got, want := cards[0], originalCards[1]; got != want {
+		t.Errorf("After Cycle(), top card = %v, want %v", got, want)
+	}
+	if got, want := cards[len(cards)-1], originalCards[0]; got != want {
+		t.Errorf("After Cycle(), bottom card = %v, want %v", got, want)
+	}
+}
+
+func TestDeckCycleEmpty(t *testing.T) {
+	d := &Deck{}
+	d.Cycle()
+	d.CycleN(3)
+
+	if got, want := d.Len(), 0; got != want {
+		t.Errorf("After Cycle() on empty deck, deck.Len() = %d, want %d", got, want)
+	}
+}
+
+func TestDeckCycleN(t *testing.T) {
+	tests := []struct {
+		name string
+		n    int
+		want []Card
+	}{
+		{"zero", 0, []Card{NewCard(Ace, Spades), NewCard(Two, Spades), NewCard(Three, Spades), NewCard(Four, Spades)}},
+		{"one", 1, []Card{NewCard(Two, Spades), NewCard(Three, Spades), NewCard(Four, Spades), NewCard(Ace, Spades)}},
+		{"three", 3, []Card{NewCard(Four, Spades), NewCard(Ace, Spades), NewCard(Two, Spades), NewCard(Three, Spades)}},
+		{"full length", 4, []Card{NewCard(Ace, Spades), NewCard(Two, Spades), NewCard(Three, Spades), NewCard(Four, Spades)}},
+		{"more than length", 6, []Card{NewCard(Three, Spades), NewCard(Four, Spades), NewCard(Ace, Spades), NewCard(Two, Spades)}},
+		{"negative", -1, []Card{NewCard(Four, Spades), NewCard(Ace, Spades), NewCard(Two, Spades), NewCard(Three, Spades)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Deck{cards: []Card{NewCard(Ace, Spades), NewCard(Two, Spades), NewCard(Three, Spades), NewCard(Four, Spades)}}
+
+			d.CycleN(tt.n)
+
+			got := d.Cards()
+			if len(got) != len(tt.want) {
+				t.Fatalf("After CycleN(%d), deck.Len() = %d, want %d", tt.n, len(got), len(tt.want))
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("After CycleN(%d), card[%d] = %v, want %v", tt.n, i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestChaCha8Shuffler(t *testing.T) {
+	seed := [32]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+
+	d1 := New()
+	d2 := New()
+	d1.ShuffleWith(NewChaCha8Shuffler(seed))
+	d2.ShuffleWith(NewChaCha8Shuffler(seed))
+
+	cards1 := d1.Cards()
+	cards2 := d2.Cards()
+	for i := range cards1 {
+		if got, want := cards2[i], cards1[i]; got != want {
+			t.Fatalf("After ShuffleWith(same ChaCha8 seed), decks differ at index %d: got %v, want %v (same seed should produce same order)", i, got, want)
+		}
+	}
+
+	sameOrder := true
+	original := New().Cards()
+	for i := range original {
+		if original[i] != cards1[i] {
+			sameOrder = false
+			break
+		}
+	}
+	if sameOrder {
+		t.Error("After ShuffleWith(ChaCha8Shuffler), card order unchanged (shuffle may not be working)")
+	}
+
+	d3 := New()
+	otherSeed := seed
+	otherSeed[0]++
+	d3.ShuffleWith(NewChaCha8Shuffler(otherSeed))
+
+	cards3 := d3.Cards()
+	different := false
+	for i := range cards1 {
+		if cards1[i] != cards3[i] {
+			different = true
+			break
+		}
+	}
+	if !different {
+		t.Error("After ShuffleWith(different ChaCha8 seeds), decks are identical, want different orders")
+	}
+}
+
+func TestDrawChunks(t *testing.T) {
+	tests := []struct {
+		name      string
+		deckSize  int
+		chunkSize int
+		wantSizes []int
+	}{
+		{"even rows", 52, 13, []int{13, 13, 13, 13}},
+		{"partial final row", 52, 7, []int{7, 7, 7, 7, 7, 7, 7, 3}},
+		{"chunk larger than deck", 5, 10, []int{5}},
+		{"single card chunks", 3, 1, []int{1, 1, 1}},
+		{"empty deck", 0, 4, []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := New()
+			for d.Len() > tt.deckSize {
+				_, _ = d.Draw()
+			}
+			originalCards := d.Cards()
+
+			chunks, err := d.DrawChunks(tt.chunkSize)
+			if err != nil {
+				t.Fatalf("DrawChunks(%d) got error: %v, want nil", tt.chunkSize, err)
+			}
+
+			if got, want := len(chunks), len(tt.wantSizes); got != want {
+				t.Fatalf("DrawChunks(%d) = %d chunks, want %d", tt.chunkSize, got, want)
+			}
+
+			i := 0
+			for c, chunk := range chunks {
+				if got, want := len(chunk), tt.wantSizes[c]; got != want {
+					t.Errorf("DrawChunks(%d)[%d] = %d cards, want %d", tt.chunkSize, c, got, want)
+				}
+				for _, card := range chunk {
+					if got, want := card, originalCards[i]; got != want {
+						t.Errorf("DrawChunks(%d) card %d = %v, want %v", tt.chunkSize, i, got, want)
+					}
+					i++
+				}
+			}
+
+			if got, want := d.Len(), 0; got != want {
+				t.Errorf("After DrawChunks(%d), deck.Len() = %d, want %d", tt.chunkSize, got, want)
+			}
+		})
+	}
+}
+
+func TestDrawChunksValidation(t *testing.T) {
+	for _, chunkSize := range []int{0, -1} {
+		d := New()
+		chunks, err := d.DrawChunks(chunkSize)
+		if err == nil {
+			t.Fatalf("DrawChunks(%d) got nil error, want error", chunkSize)
+		}
+		if got, want := err.Error(), fmt.Sprintf("chunk size must be at least 1, got %d", chunkSize); got != want {
+			t.Errorf("DrawChunks(%d) error = %q, want %q", chunkSize, got, want)
+		}
+		if chunks != nil {
+			t.Errorf("DrawChunks(%d) returned chunks = %v, want nil when error occurs", chunkSize, chunks)
+		}
+		if got, want := d.Len(), 52; got != want {
+			t.Errorf("After DrawChunks(%d) error, deck.Len() = %d, want %d (deck should be unchanged)", chunkSize, got, want)
+		}
+	}
+}
+
+func TestDrawChunksIndependentSlices(t *testing.T) {
+	d := New()
+	chunks, err := d.DrawChunks(2)
+	if err != nil {
+		t.Fatalf("DrawChunks(2) unexpected error: %v", err)
+	}
+
+	want := chunks[1][0]
+	chunks[0] = append(chunks[0], NewRedJoker())
+
+	if got := chunks[1][0]; got != want {
+		t.Errorf("After appending to chunks[0], chunks[1][0] = %v, want %v (chunks should not share backing arrays)", got, want)
+	}
+}
+
+func TestDeckFingerprint(t *testing.T) {
+	d1 := New()
+	d2 := New()
+
+	if got, want := d1.Fingerprint(), d2.Fingerprint(); got != want {
+		t.Errorf("Fingerprint() of identical decks = %#x and %#x, want equal", got, want)
+	}
+
+	// FNV-1a 64-bit offset basis for an empty input
+	if got, want := (&Deck{}).Fingerprint(), uint64(0xcbf29ce484222325); got != want {
+		t.Errorf("Fingerprint() of empty deck = %#x, want %#x", got, want)
+	}
+
+	d2.Cycle()
+	if d1.Fingerprint() == d2.Fingerprint() {
+		t.Error("Fingerprint() of reordered decks are equal, want different (hash should be order-sensitive)")
+	}
+
+	d3 := New()
+	_, _ = d3.Draw()
+	if d1.Fingerprint() == d3.Fingerprint() {
+		t.Error("Fingerprint() of decks with different cards are equal, want different")
+	}
+}
+
+func TestDeckChecksum(t *testing.T) {
+	d1 := New()
+	d2 := New()
+
+	if got, want := d1.Checksum(), d2.Checksum(); got != want {
+		t.Errorf("Checksum() of identical decks = %x and %x, want equal", got, want)
+	}
+
+	d2.Cycle()
+	if d1.Checksum() == d2.Checksum() {
+		t.Error("Checksum() of reordered decks are equal, want different (hash should be order-sensitive)")
+	}
+
+	data, err := d1.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() got error: %v, want nil", err)
+	}
+	received := &Deck{}
+	if err := received.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary() got error: %v, want nil", err)
+	}
+	if got, want := received.Checksum(), d1.Checksum(); got != want {
+		t.Errorf("Checksum() after marshal round trip = %x, want %x", got, want)
+	}
+}
+
+func TestShuffleCommit(t *testing.T) {
+	seed := []byte("0123456789abcdef0123456789abcdef")
+
+	d1 := New()
+	original := d1.Cards()
+	commitment := ShuffleCommit(d1, seed)
+
+	if !VerifyCommitment(commitment, seed) {
+		t.Error("VerifyCommitment(commitment, seed) = false, want true")
+	}
+	if VerifyCommitment(commitment, []byte("another seed")) {
+		t.Error("VerifyCommitment(commitment, wrong seed) = true, want false")
+	}
+
+	d2 := New()
+	if got, want := ShuffleCommit(d2, seed), commitment; got != want {
+		t.Errorf("ShuffleCommit() with same seed = %x, want %x", got, want)
+	}
+	if got, want := d2.Fingerprint(), d1.Fingerprint(); got != want {
+		t.Errorf("ShuffleCommit() with same seed produced different orders: fingerprint %#x, want %#x", got, want)
+	}
+
+	if d1.Fingerprint() == New().Fingerprint() {
+		t.Error("After ShuffleCommit(), card order unchanged (shuffle may not be working)")
+	}
+
+	// The commitment must not be usable as the shuffle key.
+	d3 := New()
+	d3.ShuffleWith(sourceShuffler{src: randv2.NewChaCha8(commitment)})
+	if d3.Fingerprint() == d1.Fingerprint() {
+		t.Error("Shuffling with the commitment reproduces the committed shuffle, want the commitment to reveal nothing")
+	}
+
+	if !VerifyShuffle(original, seed, d1) {
+		t.Error("VerifyShuffle(original, seed, result) = false, want true")
+	}
+}
+
+func TestShuffleCommitGolden(t *testing.T) {
+	// These values are part of the commit-reveal contract and must never
+	// change, or seeds revealed after play would no longer verify
+	seed := []byte("0123456789abcdef0123456789abcdef")
+	d := New()
+	ShuffleCommit(d, seed)
+
+	want := []Card{
+		NewCard(Two, Clubs),
+		NewCard(Eight, Spades),
+		NewCard(Four, Clubs),
+		NewCard(Eight, Hearts),
+		NewCard(Ten, Spades),
+		NewCard(Three, Diamonds),
+		NewCard(Ace, Diamonds),
+		NewCard(Four, Hearts),
+	}
+	if got := d.Cards()[:len(want)]; !slices.Equal(got, want) {
+		t.Errorf("After ShuffleCommit(), top cards = %v, want %v", got, want)
+	}
+
+	if !VerifyShuffle(New().Cards(), seed, d) {
+		t.Error("VerifyShuffle() = false for the golden shuffle, want true")
+	}
+}
+
+func TestVerifyShuffleRejects(t *testing.T) {
+	seed := []byte("0123456789abcdef0123456789abcdef")
+
+	tests := []struct {
+		name   string
+		mutate func(original []Card, result *Deck) ([]Card, []byte)
+	}{
+		{
+			name: "wrong seed",
+			mutate: func(original []Card, result *Deck) ([]Card, []byte) {
+				return original, []byte("fedcba9876543210fedcba9876543210")
+			},
+		},
+		{
+			name: "tampered result",
+			mutate: func(original []Card, result *Deck) ([]Card, []byte) {
+				result.Cycle()
+				return original, seed
+			},
+		},
+		{
+			name: "missing card",
+			mutate: func(original []Card, result *Deck) ([]Card, []byte) {
+				_, _ = result.Draw()
+				return original, seed
+			},
+		},
+		{
+			name: "different original order",
+			mutate: func(original []Card, result *Deck) ([]Card, []byte) {
+				original[0], original[1] = original[1], original[0]
+				return original, seed
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := New()
+			original := d.Cards()
+			ShuffleCommit(d, seed)
+
+			original, verifySeed := tt.mutate(original, d)
+			if VerifyShuffle(original, verifySeed, d) {
+				t.Error("VerifyShuffle() = true, want false")
+			}
+		})
+	}
+}
+
+func TestDeckRemoveCards(t *testing.T) {
+	d := New()
+	known := []Card{NewCard(Ace, Spades), NewCard(King, Hearts), NewCard(Two, Clubs)}
+
+	if err := d.RemoveCards(known); err != nil {
+		t.Fatalf("RemoveCards(%v) got error: %v, want nil", known, err)
+	}
+
+	if got, want := d.Len(), 49; got != want {
+		t.Errorf("After RemoveCards(), deck.Len() = %d, want %d", got, want)
+	}
+
+	for _, card := range d.Cards() {
+		for _, removed := range known {
+			if card == removed {
+				t.Errorf("After RemoveCards(), deck still contains %v", card)
+			}
+		}
+	}
+
+	// Remaining cards keep their relative order
+	top, _ := d.Peek()
+	if got, want := top, NewCard(Two, Spades); got != want {
+		t.Errorf("After RemoveCards(), top card = %v, want %v", got, want)
+	}
+}
+
+func TestDeckRemoveCardsMultiDeck(t *testing.T) {
+	d, _ := NewMultiple(2)
+	aceOfSpades := NewCard(Ace, Spades)
+
+	if err := d.RemoveCards([]Card{aceOfSpades, aceOfSpades}); err != nil {
+		t.Fatalf("RemoveCards() of both copies got error: %v, want nil", err)
+	}
+	if got, want := d.Len(), 102; got != want {
+		t.Errorf("After RemoveCards(), deck.Len() = %d, want %d", got, want)
+	}
+
+	err := d.RemoveCards([]Card{aceOfSpades})
+	if err == nil {
+		t.Fatal("RemoveCards() of a third copy got nil error, want error")
+	}
+}
+
+func TestDeckRemoveCardsMissing(t *testing.T) {
+	tests := []struct {
+		name    string
+		cards   []Card
+		wantErr string
+	}{
+		{
+			name:    "joker not in standard deck",
+			cards:   []Card{NewCard(Ace, Spades), NewRedJoker()},
+			wantErr: "card not in deck: Joker (Red)",
+		},
+		{
+			name:    "duplicate of single card",
+			cards:   []Card{NewCard(Queen, Diamonds), NewCard(Queen, Diamonds)},
+			wantErr: "card not in deck: Queen of Diamonds",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := New()
+			originalCards := d.Cards()
+
+			err := d.RemoveCards(tt.cards)
+			if err == nil {
+				t.Fatalf("RemoveCards(%v) got nil error, want %q", tt.cards, tt.wantErr)
+			}
+			if got, want := err.Error(), tt.wantErr; got != want {
+				t.Errorf("RemoveCards(%v) error = %q, want %q", tt.cards, got, want)
+			}
+
+			currentCards := d.Cards()
+			if got, want := len(currentCards), len(originalCards); got != want {
+				t.Fatalf("After RemoveCards() error, deck.Len() = %d, want %d (deck should be unchanged)", got, want)
+			}
+			for i := range originalCards {
+				if got, want := currentCards[i], originalCards[i]; got != want {
+					t.Errorf("After RemoveCards() error, card[%d] = %v, want %v (unchanged)", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestDeckSnapshotRestore(t *testing.T) {
+	d := New()
+	d.ShuffleWithSeed(42)
+	original := d.Cards()
+
+	snap := d.Snapshot()
+
+	for trial := 0; trial < 3; trial++ {
+		if err := d.Restore(snap); err != nil {
+			t.Fatalf("Restore() got error: %v, want nil", err)
+		}
+
+		restored := d.Cards()
+		if got, want := len(restored), len(original); got != want {
+			t.Fatalf("Trial %d: after Restore(), deck.Len() = %d, want %d", trial, got, want)
+		}
+		for i := range original {
+			if got, want := restored[i], original[i]; got != want {
+				t.Errorf("Trial %d: after Restore(), card[%d] = %v, want %v", trial, i, got, want)
+			}
+		}
+
+		// Mutate the deck in a variety of ways before the next restore
+		_, _ = d.DrawN(5)
+		d.Sort()
+		d.Add(NewRedJoker())
+		d.AddToTop(NewBlackJoker())
+	}
+}
+
+func TestDeckSnapshotIsolation(t *testing.T) {
+	d := New()
+	snap := d.Snapshot()
+
+	// Changes made before restoring must not leak into the snapshot
+	d.ShuffleWithSeed(7)
+	_, _ = d.Draw()
+
+	if err := d.Restore(snap); err != nil {
+		t.Fatalf("Restore() got error: %v, want nil", err)
+	}
+	if got, want := d.Fingerprint(), New().Fingerprint(); got != want {
+		t.Errorf("After Restore(), fingerprint = %#x, want %#x (deck should match snapshot)", got, want)
+	}
+}
+
+func TestDeckRestoreDifferentDeck(t *testing.T) {
+	d1 := New()
+	d2 := New()
+	_, _ = d2.Draw()
+
+	err := d2.Restore(d1.Snapshot())
+	if err == nil {
+		t.Fatal("Restore() with another deck's snapshot got nil error, want error")
+	}
+	if got, want := err.Error(), "snapshot was taken from a different deck"; got != want {
+		t.Errorf("Restore() error = %q, want %q", got, want)
+	}
+	if got, want := d2.Len(), 51; got != want {
+		t.Errorf("After Restore() error, deck.Len() = %d, want %d (deck should be unchanged)", got, want)
+	}
+}
+
+func TestDeckRestoreAllocs(t *testing.T) {
+	d := New()
+	snap := d.Snapshot()
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = d.Restore(snap)
+		_, _ = d.Draw()
+	})
+	if allocs != 0 {
+		t.Errorf("Restore() allocated %v times per run, want 0", allocs)
+	}
+}
+
+func BenchmarkRestore(b *testing.B) {
+	d := New()
+	d.SecureShuffle()
+	snap := d.Snapshot()
+
+	for b.Loop() {
+		_ = d.Restore(snap)
+		_, _ = d.DrawN(5)
+	}
+}
+
+func TestMarshalDecks(t *testing.T) {
+	d1 := New()
+	d1.ShuffleWithSeed(1)
+	d2 := NewWithJokers()
+	d3 := &Deck{}
+	decks := []*Deck{d1, d2, d3}
+
+	data, err := MarshalDecks(decks)
+	if err != nil {
+		t.Fatalf("MarshalDecks() got error: %v, want nil", err)
+	}
+
+	if got, want := len(data), 4+d1.Size()+d2.Size()+d3.Size(); got != want {
+		t.Errorf("MarshalDecks() = %d bytes, want %d", got, want)
+	}
+
+	decoded, err := UnmarshalDecks(data)
+	if err != nil {
+		t.Fatalf("UnmarshalDecks() got error: %v, want nil", err)
+	}
+
+	if got, want := len(decoded), len(decks); got != want {
+		t.Fatalf("UnmarshalDecks() = %d decks, want %d", got, want)
+	}
+	for i := range decks {
+		if got, want := decoded[i].Len(), decks[i].Len(); got != want {
+			t.Errorf("UnmarshalDecks()[%d].Len() = %d, want %d", i, got, want)
+		}
+		if got, want := decoded[i].Checksum(), decks[i].Checksum(); got != want {
+			t.Errorf("UnmarshalDecks()[%d] checksum = %x, want %x", i, got, want)
+		}
+	}
+}
+
+func TestMarshalDecksEmpty(t *testing.T) {
+	data, err := MarshalDecks(nil)
+	if err != nil {
+		t.Fatalf("MarshalDecks(nil) got error: %v, want nil", err)
+	}
+
+	decoded, err := UnmarshalDecks(data)
+	if err != nil {
+		t.Fatalf("UnmarshalDecks() got error: %v, want nil", err)
+	}
+	if got, want := len(decoded), 0; got != want {
+		t.Errorf("UnmarshalDecks() = %d decks, want %d", got, want)
+	}
+}
+
+func TestMarshalDecksNil(t *testing.T) {
+	_, err := MarshalDecks([]*Deck{New(), nil})
+	if err == nil {
+		t.Fatal("MarshalDecks() with nil deck got nil error, want error")
+	}
+	if got, want := err.Error(), "deck at index 1 is nil"; got != want {
+		t.Errorf("MarshalDecks() error = %q, want %q", got, want)
+	}
+}
+
+func TestUnmarshalDecksErrors(t *testing.T) {
+	valid, _ := MarshalDecks([]*Deck{New(), New()})
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr string
+	}{
+		{"empty", []byte{}, "invalid data: too short"},
+		{"short count", []byte{1, 0}, "invalid data: too short"},
+		{"impossible count", []byte{0xFF, 0xFF, 0xFF, 0xFF}, "invalid data: truncated, expected 4294967295 decks"},
+		{"truncated header", valid[:4+56+2], "invalid data: truncated header for deck 1"},
+		{"truncated cards", valid[:len(valid)-1], "invalid data: truncated deck 1: expected 52 cards, got 51"},
+		{"trailing bytes", append(append([]byte{}, valid...), 0x01), "invalid data: 1 trailing bytes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decks, err := UnmarshalDecks(tt.data)
+			if err == nil {
+				t.Fatalf("UnmarshalDecks() got nil error, want %q", tt.wantErr)
+			}
+			if got, want := err.Error(), tt.wantErr; got != want {
+				t.Errorf("UnmarshalDecks() error = %q, want %q", got, want)
+			}
+			if decks != nil {
+				t.Errorf("UnmarshalDecks() returned decks = %v, want nil when error occurs", decks)
+			}
+		})
+	}
+}
+
+func TestDeckWriteToReadFrom(t *testing.T) {
+	tests := []struct {
+		name string
+		deck func() *Deck
+	}{
+		{"empty deck", func() *Deck { return &Deck{} }},
+		{"standard deck", New},
+		{"six deck shoe", func() *Deck {
+			d, _ := NewMultipleWithJokers(6)
+			d.ShuffleWithSeed(6)
+			return d
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := tt.deck()
+
+			var buf bytes.Buffer
+			n, err := d.WriteTo(&buf)
+			if err != nil {
+				t.Fatalf("WriteTo() got error: %v, want nil", err)
+			}
+			if got, want := n, int64(d.Size()); got != want {
+				t.Errorf("WriteTo() = %d bytes, want %d", got, want)
+			}
+
+			data, _ := d.MarshalBinary()
+			if !bytes.Equal(buf.Bytes(), data) {
+				t.Errorf("WriteTo() output differs from MarshalBinary()")
+			}
+
+			decoded := &Deck{}
+			n, err = decoded.ReadFrom(&buf)
+			if err != nil {
+				t.Fatalf("ReadFrom() got error: %v, want nil", err)
+			}
+			if got, want := n, int64(d.Size()); got != want {
+				t.Errorf("ReadFrom() = %d bytes, want %d", got, want)
+			}
+			if got, want := decoded.Checksum(), d.Checksum(); got != want {
+				t.Errorf("ReadFrom() checksum = %x, want %x", got, want)
+			}
+		})
+	}
+}
+
+func TestDeckReadFromConsecutive(t *testing.T) {
+	var buf bytes.Buffer
+	_, _ = New().WriteTo(&buf)
+	_, _ = NewWithJokers().WriteTo(&buf)
+
+	d1, d2 := &Deck{}, &Deck{}
+	if _, err := d1.ReadFrom(&buf); err != nil {
+		t.Fatalf("first ReadFrom() got error: %v, want nil", err)
+	}
+	if _, err := d2.ReadFrom(&buf); err != nil {
+		t.Fatalf("second ReadFrom() got error: %v, want nil", err)
+	}
+
+	if got, want := d1.Len(), 52; got != want {
+		t.Errorf("first ReadFrom() deck.Len() = %d, want %d", got, want)
+	}
+	if got, want := d2.Len(), 54; got != want {
+		t.Errorf("second ReadFrom() deck.Len() = %d, want %d", got, want)
+	}
+}
+
+func TestDeckReadFromErrors(t *testing.T) {
+	valid, _ := New().MarshalBinary()
+
+	tests := []struct {
+		name      string
+		data      []byte
+		wantRead  int64
+		wantErrIs error
+	}{
+		{"empty", []byte{}, 0, io.EOF},
+		{"short header", []byte{52, 0}, 2, io.ErrUnexpectedEOF},
+		{"no cards", valid[:4], 4, io.ErrUnexpectedEOF},
+		{"truncated cards", valid[:30], 30, io.ErrUnexpectedEOF},
+		{"huge header", []byte{0xFF, 0xFF, 0xFF, 0xFF, 0x01}, 5, io.ErrUnexpectedEOF},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := New()
+			n, err := d.ReadFrom(bytes.NewReader(tt.data))
+			if err == nil {
+				t.Fatal("ReadFrom() got nil error, want error")
+			}
+			if !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("ReadFrom() error = %v, want wrapping %v", err, tt.wantErrIs)
+			}
+			if got, want := n, tt.wantRead; got != want {
+				t.Errorf("ReadFrom() = %d bytes, want %d", got, want)
+			}
+			if got, want := d.Len(), 52; got != want {
+				t.Errorf("After ReadFrom() error, deck.Len() = %d, want %d (deck should be unchanged)", got, want)
+			}
+		})
+	}
+}
+
+type failingWriter struct {
+	remaining int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if len(p) > w.remaining {
+		n := w.remaining
+		w.remaining = 0
+		return n, errors.New("write failed")
+	}
+	w.remaining -= len(p)
+	return len(p), nil
+}
+
+func TestDeckWriteToError(t *testing.T) {
+	d, _ := NewMultiple(20)
+
+	for _, limit := range []int{0, 100, 600} {
+		n, err := d.WriteTo(&failingWriter{remaining: limit})
+		if err == nil {
+			t.Fatalf("WriteTo() with writer failing after %d bytes got nil error, want error", limit)
+		}
+		if got, want := n, int64(limit); got != want {
+			t.Errorf("WriteTo() with writer failing after %d bytes = %d bytes, want %d", limit, got, want)
+		}
+	}
+}
+
+func BenchmarkWriteTo(b *testing.B) {
+	d, _ := NewMultiple(6)
+	for b.Loop() {
+		_, _ = d.WriteTo(io.Discard)
+	}
+}
+
+// chunkRecorder records the size of the largest write it receives.
+type chunkRecorder struct {
+	writes, largest, total int
+}
+
+func (w *chunkRecorder) Write(p []byte) (int, error) {
+	w.writes++
+	w.largest = max(w.largest, len(p))
+	w.total += len(p)
+	return len(p), nil
+}
+
+func TestDeckWriteToLargeShoe(t *testing.T) {
+	d, _ := NewMultiple(100)
+	var w chunkRecorder
+	n, err := d.WriteTo(&w)
+	if err != nil {
+		t.Fatalf("WriteTo() got error: %v, want nil", err)
+	}
+	if got, want := n, int64(d.Size()); got != want {
+		t.Errorf("WriteTo() = %d bytes, want %d", got, want)
+	}
+	if w.largest > streamChunkSize {
+		t.Errorf("WriteTo() wrote a chunk of %d bytes, want at most %d", w.largest, streamChunkSize)
+	}
+	if got, want := w.writes, (d.Size()+streamChunkSize-1)/streamChunkSize; got != want {
+		t.Errorf("WriteTo() made %d writes, want %d", got, want)
+	}
+
+	// Memory use does not grow with the deck
+	if allocs := testing.AllocsPerRun(10, func() { _, _ = d.WriteTo(io.Discard) }); allocs > 1 {
+		t.Errorf("WriteTo() allocated %v times, want at most 1", allocs)
+	}
+}
+
+// BenchmarkMarshalBinaryLargeShoe and BenchmarkWriteToLargeShoe compare the
+// memory used to encode a 100-deck research shoe.
+func BenchmarkMarshalBinaryLargeShoe(b *testing.B) {
+	d, _ := NewMultiple(100)
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _ = d.MarshalBinary()
+	}
+}
+
+func BenchmarkWriteToLargeShoe(b *testing.B) {
+	d, _ := NewMultiple(100)
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _ = d.WriteTo(io.Discard)
+	}
+}
+
+func TestDeckValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		deck    *Deck
+		wantErr string
+	}{
+		{"empty deck", &Deck{}, ""},
+		{"standard deck", New(), ""},
+		{"deck with jokers", NewWithJokers(), ""},
+		{"rank zero", &Deck{cards: []Card{NewCard(Ace, Spades), Card(0x00)}}, "invalid card at index 1: 0x00"},
+		{"rank above black joker", &Deck{cards: []Card{Card(0x10)}}, "invalid card at index 0: 0x10"},
+		{"rank out of range with suit", &Deck{cards: []Card{NewCard(King, Clubs), Card(0xFF)}}, "invalid card at index 1: 0xff"},
+		{"red joker with wrong suit", &Deck{cards: []Card{NewCard(RedJoker, Clubs)}}, "invalid card at index 0: 0xce"},
+		{"black joker with wrong suit", &Deck{cards: []Card{NewCard(BlackJoker, Hearts)}}, "invalid card at index 0: 0x4f"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.deck.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() got error: %v, want nil", err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("Validate() got nil error, want %q", tt.wantErr)
+			}
+			if got, want := err.Error(), tt.wantErr; got != want {
+				t.Errorf("Validate() error = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestDeckUnmarshalBinaryInvalidCards(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr string
+	}{
+		{"rank zero", []byte{0x02, 0x00, 0x00, 0x00, 0x01, 0x00}, "invalid data: invalid card at index 1: 0x00"},
+		{"rank out of range", []byte{0x01, 0x00, 0x00, 0x00, 0x3F}, "invalid data: invalid card at index 0: 0x3f"},
+		{"joker with wrong suit", []byte{0x03, 0x00, 0x00, 0x00, 0x01, 0x4E, 0xCE}, "invalid data: invalid card at index 2: 0xce"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := New()
+			err := d.UnmarshalBinary(tt.data)
+			if err == nil {
+				t.Fatalf("UnmarshalBinary(%v) got nil error, want %q", tt.data, tt.wantErr)
+			}
+			if got, want := err.Error(), tt.wantErr; got != want {
+				t.Errorf("UnmarshalBinary(%v) error = %q, want %q", tt.data, got, want)
+			}
+			if got, want := d.Len(), 52; got != want {
+				t.Errorf("After UnmarshalBinary() error, deck.Len() = %d, want %d (deck should be unchanged)", got, want)
+			}
+
+			_, err = New().ReadFrom(bytes.NewReader(tt.data))
+			if got, want := fmt.Sprint(err), tt.wantErr; got != want {
+				t.Errorf("ReadFrom(%v) error = %q, want %q", tt.data, got, want)
+			}
+		})
+	}
+}
+
+func TestUnmarshalDecksInvalidCard(t *testing.T) {
+	data := []byte{
+		0x02, 0x00, 0x00, 0x00, // 2 decks
+		0x01, 0x00, 0x00, 0x00, 0x01, // deck 0: Ace of Spades
+		0x02, 0x00, 0x00, 0x00, 0x02, 0x20, // deck 1: 2 of Spades, invalid
+	}
+
+	_, err := UnmarshalDecks(data)
+	if err == nil {
+		t.Fatal("UnmarshalDecks() got nil error, want error")
+	}
+	if got, want := err.Error(), "invalid data: deck 1: invalid card at index 1: 0x20"; got != want {
+		t.Errorf("UnmarshalDecks() error = %q, want %q", got, want)
+	}
+}
+
+func TestCardIsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		card Card
+		want bool
+	}{
+		{"ace of spades", NewCard(Ace, Spades), true},
+		{"king of clubs", NewCard(King, Clubs), true},
+		{"red joker", NewRedJoker(), true},
+		{"black joker", NewBlackJoker(), true},
+		{"zero value", Card(0), false},
+		{"rank zero with suit", NewCard(0, Hearts), false},
+		{"rank above black joker", Card(0x10), false},
+		{"red joker with wrong suit", NewCard(RedJoker, Spades), false},
+		{"black joker with wrong suit", NewCard(BlackJoker, Clubs), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.card.IsValid(); got != tt.want {
+				t.Errorf("Card(%#02x).IsValid() = %v, want %v", byte(tt.card), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewCardChecked(t *testing.T) {
+	tests := []struct {
+		name    string
+		rank    Rank
+		suit    Suit
+		want    Card
+		wantErr string
+	}{
+		{"ace of spades", Ace, Spades, NewCard(Ace, Spades), ""},
+		{"queen of clubs", Queen, Clubs, NewCard(Queen, Clubs), ""},
+		{"red joker", RedJoker, Hearts, NewRedJoker(), ""},
+		{"black joker", BlackJoker, Spades, NewBlackJoker(), ""},
+		{"rank zero", 0, Spades, Card(0), "invalid rank: 0"},
+		{"rank too high", BlackJoker + 1, Spades, Card(0), "invalid rank: 16"},
+		{"rank overflows mask", 65, Spades, Card(0), "invalid rank: 65"},
+		{"suit too high", Ace, Clubs + 1, Card(0), "invalid suit: 4"},
+		{"red joker with wrong suit", RedJoker, Clubs, Card(0), "invalid suit Clubs for Joker (Red)"},
+		{"black joker with wrong suit", BlackJoker, Diamonds, Card(0), "invalid suit Diamonds for Joker (Black)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewCardChecked(tt.rank, tt.suit)
+			if tt.wantErr != "" {
+				if err == nil {
+					t.Fatalf("NewCardChecked(%d, %d) got nil error, want %q", tt.rank, tt.suit, tt.wantErr)
+				}
+				if got, want := err.Error(), tt.wantErr; got != want {
+					t.Errorf("NewCardChecked(%d, %d) error = %q, want %q", tt.rank, tt.suit, got, want)
+				}
+			} else if err != nil {
+				t.Fatalf("NewCardChecked(%d, %d) got error: %v, want nil", tt.rank, tt.suit, err)
+			}
+
+			if got != tt.want {
+				t.Errorf("NewCardChecked(%d, %d) = %v, want %v", tt.rank, tt.suit, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeckProbabilityNext(t *testing.T) {
+	isAce := func(c Card) bool { return c.Rank() == Ace }
+	isHeart := func(c Card) bool { return c.Suit() == Hearts }
+	never := func(Card) bool { return false }
+	always := func(Card) bool { return true }
+
+	tests := []struct {
+		name      string
+		deck      *Deck
+		predicate func(Card) bool
+		want      float64
+	}{
+		{"ace from full deck", New(), isAce, 4.0 / 52},
+		{"heart from full deck", New(), isHeart, 13.0 / 52},
+		{"no match", New(), never, 0},
+		{"always match", New(), always, 1},
+		{"empty deck", &Deck{}, always, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.deck.ProbabilityNext(tt.predicate); math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("ProbabilityNext() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeckProbabilityInNext(t *testing.T) {
+	isAce := func(c Card) bool { return c.Rank() == Ace }
+	never := func(Card) bool { return false }
+
+	fourCards := &Deck{cards: []Card{NewCard(Ace, Spades), NewCard(Two, Spades), NewCard(Three, Spades), NewCard(Four, Spades)}}
+
+	tests := []struct {
+		name      string
+		deck      *Deck
+		n         int
+		predicate func(Card) bool
+		want      float64
+	}{
+		{"one card equals ProbabilityNext", New(), 1, isAce, 4.0 / 52},
+		// 1 - C(48,2)/C(52,2) = 1 - 1128/1326
+		{"ace in two cards", New(), 2, isAce, 1 - 1128.0/1326},
+		// 1 - C(48,5)/C(52,5) = 1 - 1712304/2598960
+		{"ace in five cards", New(), 5, isAce, 1 - 1712304.0/2598960},
+		{"more draws than misses", fourCards, 4, isAce, 1},
+		{"n capped at deck size", fourCards, 10, isAce, 1},
+		{"half the small deck", fourCards, 2, isAce, 0.5},
+		{"no match", New(), 10, never, 0},
+		{"zero draws", New(), 0, isAce, 0},
+		{"negative draws", New(), -1, isAce, 0},
+		{"empty deck", &Deck{}, 3, isAce, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.deck.ProbabilityInNext(tt.n, tt.predicate); math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("ProbabilityInNext(%d) = %v, want %v", tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWeightedShufflerReproducible(t *testing.T) {
+	weight := func(i int) float64 { return float64(i%4 + 1) }
+
+	d1 := New()
+	d2 := New()
+	d1.ShuffleWith(NewWeightedShuffler(99, weight))
+	d2.ShuffleWith(NewWeightedShuffler(99, weight))
+
+	if got, want := d2.Fingerprint(), d1.Fingerprint(); got != want {
+		t.Errorf("ShuffleWith(same WeightedShuffler seed) fingerprint = %#x, want %#x (same seed should produce same order)", got, want)
+	}
+
+	if d1.Fingerprint() == New().Fingerprint() {
+		t.Error("After ShuffleWith(WeightedShuffler), card order unchanged (shuffle may not be working)")
+	}
+
+	if err := d1.RemoveCards(New().Cards()); err != nil || d1.Len() != 0 {
+		t.Errorf("After ShuffleWith(WeightedShuffler), deck is not a permutation of the original: %v", err)
+	}
+}
+
+func TestWeightedShufflerNonPositiveWeights(t *testing.T) {
+	d := New()
+	cards := d.Cards()
+
+	// Only hearts have a positive weight, so they must occupy the top 13 positions
+	d.ShuffleWith(NewWeightedShuffler(7, func(i int) float64 {
+		switch cards[i].Suit() {
+		case Hearts:
+			return 1
+		case Spades:
+			return math.NaN()
+		case Diamonds:
+			return -1
+		default:
+			return 0
+		}
+	}))
+
+	for i, card := range d.Cards() {
+		if got, want := card.Suit() == Hearts, i < 13; got != want {
+			t.Errorf("After weighted shuffle, card[%d] = %v, want hearts only in the top 13", i, card)
+		}
+	}
+}
+
+func TestWeightedShufflerBias(t *testing.T) {
+	const trials = 2000
+
+	// The card at index 0 is heavily weighted and should usually rise to the top
+	weight := func(i int) float64 {
+		if i == 0 {
+			return 100
+		}
+		return 1
+	}
+
+	top := 0
+	for seed := int64(0); seed < trials; seed++ {
+		d := New()
+		d.ShuffleWith(NewWeightedShuffler(seed, weight))
+		if card, _ := d.Peek(); card == NewCard(Ace, Spades) {
+			top++
+		}
+	}
+
+	// P(top) = 100 / (100 + 51) ≈ 0.66, versus 1/52 for a uniform shuffle
+	if got := float64(top) / trials; got < 0.55 || got > 0.77 {
+		t.Errorf("Heavily weighted card was on top in %.2f of shuffles, want about 0.66", got)
+	}
+}
+
+func TestDealWithKitty(t *testing.T) {
+	d := New()
+	originalCards := d.Cards()
+
+	hands, kitty, err := d.DealWithKitty(4, 12, 4)
+	if err != nil {
+		t.Fatalf("DealWithKitty(4, 12, 4) got error: %v, want nil", err)
+	}
+
+	if got, want := len(hands), 4; got != want {
+		t.Fatalf("DealWithKitty(4, 12, 4) = %d hands, want %d", got, want)
+	}
+	for i, hand := range hands {
+		if got, want := len(hand), 12; got != want {
+			t.Errorf("DealWithKitty(4, 12, 4) hand[%d] = %d cards, want %d", i, got, want)
+		}
+		for j, card := range hand {
+			if got, want := card, originalCards[i*12+j]; got != want {
+				t.Errorf("DealWithKitty(4, 12, 4) hand[%d][%d] = %v, want %v", i, j, got, want)
+			}
+		}
+	}
+
+	if got, want := len(kitty), 4; got != want {
+		t.Fatalf("DealWithKitty(4, 12, 4) kitty = %d cards, want %d", got, want)
+	}
+	for i, card := range kitty {
+		if got, want := card, originalCards[48+i]; got != want {
+			t.Errorf("DealWithKitty(4, 12, 4) kitty[%d] = %v, want %v", i, got, want)
+		}
+	}
+
+	if got, want := d.Len(), 0; got != want {
+		t.Errorf("After DealWithKitty(4, 12, 4), deck.Len() = %d, want %d", got, want)
+	}
+}
+
+func TestDealWithKittyEmptyKitty(t *testing.T) {
+	d := New()
+
+	hands, kitty, err := d.DealWithKitty(2, 5, 0)
+	if err != nil {
+		t.Fatalf("DealWithKitty(2, 5, 0) got error: %v, want nil", err)
+	}
+	if got, want := len(hands), 2; got != want {
+		t.Errorf("DealWithKitty(2, 5, 0) = %d hands, want %d", got, want)
+	}
+	if kitty == nil || len(kitty) != 0 {
+		t.Errorf("DealWithKitty(2, 5, 0) kitty = %v, want empty non-nil slice", kitty)
+	}
+	if got, want := d.Len(), 42; got != want {
+		t.Errorf("After DealWithKitty(2, 5, 0), deck.Len() = %d, want %d", got, want)
+	}
+}
+
+func TestDealWithKittyValidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		numPlayers int
+		cardsEach  int
+		kitty      int
+		wantErr    string
+	}{
+		{"negative kitty", 4, 5, -1, "kitty size must not be negative: -1"},
+		{"zero players", 0, 5, 3, "number of players must be at least 1"},
+		{"zero cards each", 4, 0, 3, "cards per player must be at least 1"},
+		{"too many cards each", 1, 53, 0, "insufficient cards: need 53, have 52"},
+		{"kitty exceeds remaining", 4, 12, 5, "insufficient cards: need 53, have 52"},
+		{"hands exceed deck", 4, 14, 0, "insufficient cards: need 56, have 52"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := New()
+			hands, kitty, err := d.DealWithKitty(tt.numPlayers, tt.cardsEach, tt.kitty)
+			if err == nil {
+				t.Fatalf("DealWithKitty(%d, %d, %d) got nil error, want %q", tt.numPlayers, tt.cardsEach, tt.kitty, tt.wantErr)
+			}
+			if got, want := err.Error(), tt.wantErr; got != want {
+				t.Errorf("DealWithKitty(%d, %d, %d) error = %q, want %q", tt.numPlayers, tt.cardsEach, tt.kitty, got, want)
+			}
+			if hands != nil || kitty != nil {
+				t.Errorf("DealWithKitty(%d, %d, %d) returned hands = %v, kitty = %v, want nil when error occurs", tt.numPlayers, tt.cardsEach, tt.kitty, hands, kitty)
+			}
+			if got, want := d.Len(), 52; got != want {
+				t.Errorf("After DealWithKitty() error, deck.Len() = %d, want %d (deck should be unchanged)", got, want)
+			}
+		})
+	}
+}
+
+func TestCardFormat(t *testing.T) {
+	tests := []struct {
+		format string
+		card   Card
+		want   string
+	}{
+		{"%v", NewCard(Ace, Spades), "Ace of Spades"},
+		{"%s", NewCard(Ten, Diamonds), "10 of Diamonds"},
+		{"%+v", NewCard(Ace, Spades), "Ace♠"},
+		{"%+s", NewCard(Ten, Diamonds), "10♦"},
+		{"%q", NewCard(King, Hearts), `"King of Hearts"`},
+		{"%+q", NewCard(King, Hearts), `"King♥"`},
+		{"%+5v", NewCard(Ace, Spades), " Ace♠"},
+		{"%+5v", NewCard(Ten, Diamonds), "  10♦"},
+		{"%-+5v", NewCard(Ten, Diamonds), "10♦  "},
+		{"%+5v", NewRedJoker(), "  JKR"},
+		{"%16v", NewCard(Two, Clubs), "      2 of Clubs"},
+		{"%-16v|", NewBlackJoker(), "Joker (Black)   |"},
+		{"%3v", NewCard(Queen, Clubs), "Queen of Clubs"},
+		{"%d", NewCard(Ace, Hearts), "65"},
+		{"%#02x", NewCard(Queen, Clubs), "0xcc"},
+		{"%#v", NewCard(Ace, Spades), "0x1"},
+		{"%#v", NewCard(Queen, Clubs), "0xcc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			if got := fmt.Sprintf(tt.format, tt.card); got != tt.want {
+				t.Errorf("fmt.Sprintf(%q, %#02x) = %q, want %q", tt.format, byte(tt.card), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCardFormatSlice(t *testing.T) {
+	hand := []Card{NewCard(Ace, Spades), NewCard(Ten, Hearts)}
+
+	if got, want := fmt.Sprintf("%v", hand), "[Ace of Spades 10 of Hearts]"; got != want {
+		t.Errorf("fmt.Sprintf(%%v, hand) = %q, want %q", got, want)
+	}
+	if got, want := fmt.Sprintf("%+4v", hand), "[Ace♠  10♥]"; got != want {
+		t.Errorf("fmt.Sprintf(%%+4v, hand) = %q, want %q", got, want)
+	}
+}
+
+func TestDeckFindFunc(t *testing.T) {
+	isFace := func(c Card) bool { return c.Rank() >= Jack && c.Rank() <= King }
+	isHeart := func(c Card) bool { return c.Suit() == Hearts }
+	isJoker := func(c Card) bool { return c.IsJoker() }
+
+	tests := []struct {
+		name      string
+		deck      *Deck
+		predicate func(Card) bool
+		wantIndex int
+		wantCard  Card
+		wantFound bool
+	}{
+		{"first face card", New(), isFace, 10, NewCard(Jack, Spades), true},
+		{"first heart", New(), isHeart, 13, NewCard(Ace, Hearts), true},
+		{"top card matches", New(), func(Card) bool { return true }, 0, NewCard(Ace, Spades), true},
+		{"joker in standard deck", New(), isJoker, -1, Card(0), false},
+		{"joker in deck with jokers", NewWithJokers(), isJoker, 52, NewRedJoker(), true},
+		{"empty deck", &Deck{}, isFace, -1, Card(0), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			index, card, found := tt.deck.FindFunc(tt.predicate)
+			if index != tt.wantIndex || card != tt.wantCard || found != tt.wantFound {
+				t.Errorf("FindFunc() = (%d, %v, %v), want (%d, %v, %v)", index, card, found, tt.wantIndex, tt.wantCard, tt.wantFound)
+			}
+		})
+	}
+}
+
+func TestDeckFindFuncAfterDraw(t *testing.T) {
+	d := New()
+	_, _ = d.DrawN(3)
+
+	index, card, found := d.FindFunc(func(c Card) bool { return c.Rank() == Five })
+	if index != 1 || card != NewCard(Five, Spades) || !found {
+		t.Errorf("FindFunc() after DrawN(3) = (%d, %v, %v), want (1, %v, true)", index, card, found, NewCard(Five, Spades))
+	}
+	if got, want := d.Len(), 49; got != want {
+		t.Errorf("After FindFunc(), deck.Len() = %d, want %d (deck should be unchanged)", got, want)
+	}
+}
+
+func TestDeckSwap(t *testing.T) {
+	d := New()
+
+	if err := d.Swap(0, 51); err != nil {
+		t.Fatalf("Swap(0, 51) got error: %v, want nil", err)
+	}
+
+	cards := d.Cards()
+	if got, want := cards[0], NewCard(King, Clubs); got != want {
+		t.Errorf("After Swap(0, 51), card[0] = %v, want %v", got, want)
+	}
+	if got, want := cards[51], NewCard(Ace, Spades); got != want {
+		t.Errorf("After Swap(0, 51), card[51] = %v, want %v", got, want)
+	}
+
+	if err := d.Swap(5, 5); err != nil {
+		t.Fatalf("Swap(5, 5) got error: %v, want nil", err)
+	}
+	if got, want := d.Cards()[5], NewCard(Six, Spades); got != want {
+		t.Errorf("After Swap(5, 5), card[5] = %v, want %v", got, want)
+	}
+}
+
+func TestDeckSwapOutOfRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		deck    *Deck
+		i, j    int
+		wantErr string
+	}{
+		{"negative first", New(), -1, 3, "index out of range: -1 (deck has 52 cards)"},
+		{"negative second", New(), 3, -2, "index out of range: -2 (deck has 52 cards)"},
+		{"first too large", New(), 52, 0, "index out of range: 52 (deck has 52 cards)"},
+		{"second too large", New(), 0, 100, "index out of range: 100 (deck has 52 cards)"},
+		{"empty deck", &Deck{}, 0, 0, "index out of range: 0 (deck has 0 cards)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := tt.deck.Fingerprint()
+
+			err := tt.deck.Swap(tt.i, tt.j)
+			if err == nil {
+				t.Fatalf("Swap(%d, %d) got nil error, want %q", tt.i, tt.j, tt.wantErr)
+			}
+			if got, want := err.Error(), tt.wantErr; got != want {
+				t.Errorf("Swap(%d, %d) error = %q, want %q", tt.i, tt.j, got, want)
+			}
+			if !errors.Is(err, ErrInvalidArgument) {
+				t.Errorf("Swap(%d, %d) error = %v, want ErrInvalidArgument", tt.i, tt.j, err)
+			}
+			if got, want := tt.deck.Fingerprint(), before; got != want {
+				t.Errorf("After Swap(%d, %d) error, deck changed", tt.i, tt.j)
+			}
+		})
+	}
+}
+
+// sequenceSource is a math/rand Source64 that returns a fixed sequence of values.
+type sequenceSource struct {
+	values []uint64
+	next   int
+}
+
+func (s *sequenceSource) Uint64() uint64 {
+	v := s.values[s.next%len(s.values)]
+	s.next++
+	return v
+}
+
+func (s *sequenceSource) Int63() int64 { return int64(s.Uint64() >> 1) }
+
+func (s *sequenceSource) Seed(int64) {}
+
+func TestSourceShuffler(t *testing.T) {
+	d1 := New()
+	d2 := New()
+	d1.ShuffleWith(NewSourceShuffler(mathrand.NewSource(2024).(mathrand.Source64)))
+	d2.ShuffleWith(NewSourceShuffler(mathrand.NewSource(2024).(mathrand.Source64)))
+
+	if got, want := d2.Fingerprint(), d1.Fingerprint(); got != want {
+		t.Errorf("ShuffleWith(same seeded source) fingerprint = %#x, want %#x (same seed should produce same order)", got, want)
+	}
+	if d1.Fingerprint() == New().Fingerprint() {
+		t.Error("After ShuffleWith(NewSourceShuffler()), card order unchanged (shuffle may not be working)")
+	}
+	if err := d1.RemoveCards(New().Cards()); err != nil || d1.Len() != 0 {
+		t.Errorf("After ShuffleWith(NewSourceShuffler()), deck is not a permutation of the original: %v", err)
+	}
+}
+
+func TestSourceShufflerUsesSource(t *testing.T) {
+	// A source that always returns 1 maps every draw to j = 0, so each step
+	// swaps position i with the top card.
+	d := &Deck{cards: []Card{NewCard(Ace, Spades), NewCard(Two, Spades), NewCard(Three, Spades)}}
+	d.ShuffleWith(NewSourceShuffler(&sequenceSource{values: []uint64{1}}))
+
+	want := []Card{NewCard(Two, Spades), NewCard(Three, Spades), NewCard(Ace, Spades)}
+	for i, card := range d.Cards() {
+		if card != want[i] {
+			t.Errorf("After ShuffleWith(constant source), card[%d] = %v, want %v", i, card, want[i])
+		}
+	}
+}
+
+func TestSourceShufflerRejectsBiasedValues(t *testing.T) {
+	// For n = 3, values whose low product bits fall below 2^64 % 3 = 1 must be
+	// rejected: 0 gives lo = 0 and is redrawn, max uint64 gives hi = 2.
+	s := sourceShuffler{src: &sequenceSource{values: []uint64{0, math.MaxUint64}}}
+	if got, want := s.uint64n(3), uint64(2); got != want {
+		t.Errorf("uint64n(3) = %d, want %d (zero should be rejected)", got, want)
+	}
+}
+
+func TestSourceShufflerUniform(t *testing.T) {
+	const trials = 30000
+
+	s := NewSourceShuffler(mathrand.NewSource(1).(mathrand.Source64))
+	var counts [4]int
+	for i := 0; i < trials; i++ {
+		d := &Deck{cards: []Card{NewCard(Ace, Spades), NewCard(Two, Spades), NewCard(Three, Spades), NewCard(Four, Spades)}}
+		d.ShuffleWith(s)
+		top, _ := d.Peek()
+		counts[top.Rank()-Ace]++
+	}
+
+	for rank, count := range counts {
+		if got := float64(count) / trials; got < 0.23 || got > 0.27 {
+			t.Errorf("Card with rank %v on top in %.3f of shuffles, want about 0.25", Rank(rank)+Ace, got)
+		}
+	}
+}
+
+func TestSetupDraw(t *testing.T) {
+	d := New()
+	originalCards := d.Cards()
+
+	hands, stock, discardTop, err := d.SetupDraw(4, 7)
+	if err != nil {
+		t.Fatalf("SetupDraw(4, 7) got error: %v, want nil", err)
+	}
+
+	if got, want := len(hands), 4; got != want {
+		t.Fatalf("SetupDraw(4, 7) = %d hands, want %d", got, want)
+	}
+	for i, hand := range hands {
+		if got, want := len(hand), 7; got != want {
+			t.Errorf("SetupDraw(4, 7) hand[%d] = %d cards, want %d", i, got, want)
+		}
+	}
+
+	if got, want := discardTop, originalCards[28]; got != want {
+		t.Errorf("SetupDraw(4, 7) discardTop = %v, want %v", got, want)
+	}
+
+	if got, want := stock.Len(), 23; got != want {
+		t.Fatalf("SetupDraw(4, 7) stock.Len() = %d, want %d", got, want)
+	}
+	for i, card := range stock.Cards() {
+		if got, want := card, originalCards[29+i]; got != want {
+			t.Errorf("SetupDraw(4, 7) stock card[%d] = %v, want %v", i, got, want)
+		}
+	}
+
+	if got, want := d.Len(), 0; got != want {
+		t.Errorf("After SetupDraw(4, 7), deck.Len() = %d, want %d", got, want)
+	}
+
+	// The stock is independent of the original deck
+	d.Add(NewRedJoker())
+	if got, want := stock.Len(), 23; got != want {
+		t.Errorf("After adding to the original deck, stock.Len() = %d, want %d", got, want)
+	}
+}
+
+func TestSetupDrawEmptyStock(t *testing.T) {
+	d := New()
+
+	_, stock, discardTop, err := d.SetupDraw(3, 17)
+	if err != nil {
+		t.Fatalf("SetupDraw(3, 17) got error: %v, want nil", err)
+	}
+	if got, want := discardTop, NewCard(King, Clubs); got != want {
+		t.Errorf("SetupDraw(3, 17) discardTop = %v, want %v", got, want)
+	}
+	if !stock.IsEmpty() {
+		t.Errorf("SetupDraw(3, 17) stock.Len() = %d, want 0", stock.Len())
+	}
+}
+
+func TestSetupDrawValidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		numPlayers int
+		cardsEach  int
+		wantErr    string
+	}{
+		{"zero players", 0, 7, "number of players must be at least 1"},
+		{"zero cards each", 4, 0, "cards per player must be at least 1"},
+		{"too many cards each", 1, 53, "insufficient cards: need 54, have 52"},
+		{"no card left for discard", 4, 13, "insufficient cards: need 53, have 52"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := New()
+			hands, stock, discardTop, err := d.SetupDraw(tt.numPlayers, tt.cardsEach)
+			if err == nil {
+				t.Fatalf("SetupDraw(%d, %d) got nil error, want %q", tt.numPlayers, tt.cardsEach, tt.wantErr)
+			}
+			if got, want := err.Error(), tt.wantErr; got != want {
+				t.Errorf("SetupDraw(%d, %d) error = %q, want %q", tt.numPlayers, tt.cardsEach, got, want)
+			}
+			if hands != nil || stock != nil || discardTop != Card(0) {
+				t.Errorf("SetupDraw(%d, %d) returned non-zero results on error", tt.numPlayers, tt.cardsEach)
+			}
+			if got, want := d.Len(), 52; got != want {
+				t.Errorf("After SetupDraw() error, deck.Len() = %d, want %d (deck should be unchanged)", got, want)
+			}
+		})
+	}
+}
+
+func TestCardOrdinal(t *testing.T) {
+	tests := []struct {
+		card Card
+		want int
+	}{
+		{NewCard(Ace, Spades), 0},
+		{NewCard(King, Spades), 12},
+		{NewCard(Ace, Hearts), 13},
+		{NewCard(Queen, Clubs), 50},
+		{NewCard(King, Clubs), 51},
+		{NewRedJoker(), 52},
+		{NewBlackJoker(), 53},
+		{Card(0), -1},
+		{NewCard(RedJoker, Clubs), -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%#02x", byte(tt.card)), func(t *testing.T) {
+			if got := tt.card.Ordinal(); got != tt.want {
+				t.Errorf("Card(%#02x).Ordinal() = %d, want %d", byte(tt.card), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCardOrdinalMatchesNewOrder(t *testing.T) {
+	for i, card := range NewWithJokers().Cards() {
+		if got, want := card.Ordinal(), i; got != want {
+			t.Errorf("%v.Ordinal() = %d, want %d", card, got, want)
+		}
+	}
+}
+
+func TestCardFromOrdinal(t *testing.T) {
+	for i := 0; i < 54; i++ {
+		card, err := CardFromOrdinal(i)
+		if err != nil {
+			t.Fatalf("CardFromOrdinal(%d) got error: %v, want nil", i, err)
+		}
+		if got, want := card.Ordinal(), i; got != want {
+			t.Errorf("CardFromOrdinal(%d).Ordinal() = %d, want %d", i, got, want)
+		}
+	}
+
+	for _, i := range []int{-1, 54, 255} {
+		card, err := CardFromOrdinal(i)
+		if err == nil {
+			t.Fatalf("CardFromOrdinal(%d) got nil error, want error", i)
+		}
+		if got, want := err.Error(), fmt.Sprintf("ordinal out of range: %d", i); got != want {
+			t.Errorf("CardFromOrdinal(%d) error = %q, want %q", i, got, want)
+		}
+		if card != Card(0) {
+			t.Errorf("CardFromOrdinal(%d) = %v, want zero Card on error", i, card)
+		}
+	}
+}
+
+func TestCardSet(t *testing.T) {
+	tests := []struct {
+		name  string
+		cards []Card
+		want  uint64
+	}{
+		{"empty", nil, 0},
+		{"ace of spades", []Card{NewCard(Ace, Spades)}, 1},
+		{"king of clubs", []Card{NewCard(King, Clubs)}, 1 << 51},
+		{"jokers", []Card{NewRedJoker(), NewBlackJoker()}, 1<<52 | 1<<53},
+		{"duplicates collapse", []Card{NewCard(Two, Spades), NewCard(Two, Spades)}, 1 << 1},
+		{"invalid cards ignored", []Card{Card(0), NewCard(Ace, Hearts)}, 1 << 13},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CardSet(tt.cards); got != tt.want {
+				t.Errorf("CardSet(%v) = %#x, want %#x", tt.cards, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeckBitset(t *testing.T) {
+	if got, want := New().Bitset(), uint64(1<<52-1); got != want {
+		t.Errorf("New().Bitset() = %#x, want %#x", got, want)
+	}
+	if got, want := NewWithJokers().Bitset(), uint64(1<<54-1); got != want {
+		t.Errorf("NewWithJokers().Bitset() = %#x, want %#x", got, want)
+	}
+
+	shoe, _ := NewMultiple(6)
+	if got, want := shoe.Bitset(), New().Bitset(); got != want {
+		t.Errorf("NewMultiple(6).Bitset() = %#x, want %#x", got, want)
+	}
+
+	if got, want := (&Deck{}).Bitset(), uint64(0); got != want {
+		t.Errorf("empty deck Bitset() = %#x, want %#x", got, want)
+	}
+}
+
+func TestDeckFromBitset(t *testing.T) {
+	d := DeckFromBitset(New().Bitset())
+	if got, want := d.Fingerprint(), New().Fingerprint(); got != want {
+		t.Errorf("DeckFromBitset(New().Bitset()) fingerprint = %#x, want %#x (ordinal order should match New)", got, want)
+	}
+
+	hand := []Card{NewCard(King, Clubs), NewRedJoker(), NewCard(Ace, Hearts)}
+	d = DeckFromBitset(CardSet(hand))
+	want := []Card{NewCard(Ace, Hearts), NewCard(King, Clubs), NewRedJoker()}
+	if got := d.Cards(); len(got) != len(want) {
+		t.Fatalf("DeckFromBitset() = %v, want %v", got, want)
+	}
+	for i, card := range d.Cards() {
+		if card != want[i] {
+			t.Errorf("DeckFromBitset() card[%d] = %v, want %v", i, card, want[i])
+		}
+	}
+
+	if got, want := DeckFromBitset(^uint64(0)).Len(), 54; got != want {
+		t.Errorf("DeckFromBitset(all bits).Len() = %d, want %d (bits above 53 should be ignored)", got, want)
+	}
+	if got, want := DeckFromBitset(0).Len(), 0; got != want {
+		t.Errorf("DeckFromBitset(0).Len() = %d, want %d", got, want)
+	}
+}
+
+func TestDealFrom(t *testing.T) {
+	tests := []struct {
+		name        string
+		startPlayer int
+		numPlayers  int
+		cardsEach   int
+	}{
+		{"start at first seat", 0, 4, 5},
+		{"start at middle seat", 2, 4, 5},
+		{"start at last seat", 3, 4, 13},
+		{"single player", 0, 1, 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := New()
+			originalCards := d.Cards()
+
+			hands, err := d.DealFrom(tt.startPlayer, tt.numPlayers, tt.cardsEach)
+			if err != nil {
+				t.Fatalf("DealFrom(%d, %d, %d) got error: %v, want nil", tt.startPlayer, tt.numPlayers, tt.cardsEach, err)
+			}
+
+			if got, want := len(hands), tt.numPlayers; got != want {
+				t.Fatalf("DealFrom(%d, %d, %d) = %d hands, want %d", tt.startPlayer, tt.numPlayers, tt.cardsEach, got, want)
+			}
+
+			for seat, hand := range hands {
+				if got, want := len(hand), tt.cardsEach; got != want {
+					t.Fatalf("DealFrom(%d, %d, %d)[%d] = %d cards, want %d", tt.startPlayer, tt.numPlayers, tt.cardsEach, seat, got, want)
+				}
+				// Position of this seat in the dealing order
+				turn := (seat - tt.startPlayer + tt.numPlayers) % tt.numPlayers
+				for round, card := range hand {
+					if got, want := card, originalCards[round*tt.numPlayers+turn]; got != want {
+						t.Errorf("DealFrom(%d, %d, %d)[%d][%d] = %v, want %v", tt.startPlayer, tt.numPlayers, tt.cardsEach, seat, round, got, want)
+					}
+				}
+			}
+
+			if got, want := d.Len(), 52-tt.numPlayers*tt.cardsEach; got != want {
+				t.Errorf("After DealFrom(), deck.Len() = %d, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestDealFromTopCard(t *testing.T) {
+	d := New()
+	hands, err := d.DealFrom(2, 3, 2)
+	if err != nil {
+		t.Fatalf("DealFrom(2, 3, 2) got error: %v, want nil", err)
+	}
+
+	want := [][]Card{
+		{NewCard(Two, Spades), NewCard(Five, Spades)},
+		{NewCard(Three, Spades), NewCard(Six, Spades)},
+		{NewCard(Ace, Spades), NewCard(Four, Spades)},
+	}
+	for seat := range want {
+		for i := range want[seat] {
+			if got := hands[seat][i]; got != want[seat][i] {
+				t.Errorf("DealFrom(2, 3, 2)[%d][%d] = %v, want %v", seat, i, got, want[seat][i])
+			}
+		}
+	}
+}
+
+func TestDealFromValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		startPlayer int
+		numPlayers  int
+		cardsEach   int
+		wantErr     string
+	}{
+		{"negative start", -1, 4, 5, "start player must be between 0 and 3, got -1"},
+		{"start out of range", 4, 4, 5, "start player must be between 0 and 3, got 4"},
+		{"zero players", 0, 0, 5, "number of players must be at least 1"},
+		{"zero cards each", 0, 4, 0, "cards per player must be at least 1"},
+		{"insufficient cards", 1, 4, 14, "insufficient cards: need 56, have 52"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := New()
+			hands, err := d.DealFrom(tt.startPlayer, tt.numPlayers, tt.cardsEach)
+			if err == nil {
+				t.Fatalf("DealFrom(%d, %d, %d) got nil error, want %q", tt.startPlayer, tt.numPlayers, tt.cardsEach, tt.wantErr)
+			}
+			if got, want := err.Error(), tt.wantErr; got != want {
+				t.Errorf("DealFrom(%d, %d, %d) error = %q, want %q", tt.startPlayer, tt.numPlayers, tt.cardsEach, got, want)
+			}
+			if hands != nil {
+				t.Errorf("DealFrom(%d, %d, %d) returned hands = %v, want nil when error occurs", tt.startPlayer, tt.numPlayers, tt.cardsEach, hands)
+			}
+			if got, want := d.Len(), 52; got != want {
+				t.Errorf("After DealFrom() error, deck.Len() = %d, want %d (deck should be unchanged)", got, want)
+			}
+		})
+	}
+}
+
+func TestDeckShuffleTracked(t *testing.T) {
+	d := New()
+	original := d.Cards()
+
+	perm := d.ShuffleTracked(NewSeededShuffler(11))
+
+	if got, want := len(perm), 52; got != want {
+		t.Fatalf("ShuffleTracked() permutation length = %d, want %d", got, want)
+	}
+
+	shuffled := d.Cards()
+	seen := make([]bool, len(perm))
+	for newIndex, oldIndex := range perm {
+		if oldIndex < 0 || oldIndex >= len(perm) || seen[oldIndex] {
+			t.Fatalf("ShuffleTracked() = %v, want a permutation of [0, %d)", perm, len(perm))
+		}
+		seen[oldIndex] = true
+
+		if got, want := shuffled[newIndex], original[oldIndex]; got != want {
+			t.Errorf("After ShuffleTracked(), card[%d] = %v, want original card[%d] = %v", newIndex, got, oldIndex, want)
+		}
+	}
+
+	// Tracking must not change the shuffle itself
+	untracked := New()
+	untracked.ShuffleWith(NewSeededShuffler(11))
+	if got, want := d.Fingerprint(), untracked.Fingerprint(); got != want {
+		t.Errorf("ShuffleTracked() fingerprint = %#x, want %#x (same as ShuffleWith)", got, want)
+	}
+}
+
+func TestDeckShuffleTrackedEmpty(t *testing.T) {
+	d := &Deck{}
+	perm := d.ShuffleTracked(SecureShuffler{})
+	if perm == nil || len(perm) != 0 {
+		t.Errorf("ShuffleTracked() on empty deck = %v, want empty non-nil slice", perm)
+	}
+}
+
+func TestDeckApplyPermutationReplay(t *testing.T) {
+	d := New()
+	perm := d.ShuffleTracked(NewSeededShuffler(5))
+
+	replay := New()
+	if err := replay.ApplyPermutation(perm); err != nil {
+		t.Fatalf("ApplyPermutation() got error: %v, want nil", err)
+	}
+	if got, want := replay.Fingerprint(), d.Fingerprint(); got != want {
+		t.Errorf("After ApplyPermutation(recorded), fingerprint = %#x, want %#x (should reproduce the shuffle)", got, want)
+	}
+
+	if err := d.ApplyInversePermutation(perm); err != nil {
+		t.Fatalf("ApplyInversePermutation() got error: %v, want nil", err)
+	}
+	if got, want := d.Fingerprint(), New().Fingerprint(); got != want {
+		t.Errorf("After ApplyInversePermutation(recorded), fingerprint = %#x, want %#x (should undo the shuffle)", got, want)
+	}
+}
+
+func TestDeckApplyPermutation(t *testing.T) {
+	d := &Deck{cards: []Card{NewCard(Ace, Spades), NewCard(Two, Spades), NewCard(Three, Spades)}}
+
+	if err := d.ApplyPermutation([]int{2, 0, 1}); err != nil {
+		t.Fatalf("ApplyPermutation([2 0 1]) got error: %v, want nil", err)
+	}
+	want := []Card{NewCard(Three, Spades), NewCard(Ace, Spades), NewCard(Two, Spades)}
+	for i, card := range d.Cards() {
+		if card != want[i] {
+			t.Errorf("After ApplyPermutation([2 0 1]), card[%d] = %v, want %v", i, card, want[i])
+		}
+	}
+
+	if err := d.ApplyInversePermutation([]int{2, 0, 1}); err != nil {
+		t.Fatalf("ApplyInversePermutation([2 0 1]) got error: %v, want nil", err)
+	}
+	want = []Card{NewCard(Ace, Spades), NewCard(Two, Spades), NewCard(Three, Spades)}
+	for i, card := range d.Cards() {
+		if card != want[i] {
+			t.Errorf("After ApplyInversePermutation([2 0 1]), card[%d] = %v, want %v", i, card, want[i])
+		}
+	}
+}
+
+func TestDeckApplyPermutationErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		perm    []int
+		wantErr string
+	}{
+		{"nil", nil, "invalid permutation: length 0, want 3"},
+		{"too short", []int{0, 1}, "invalid permutation: length 2, want 3"},
+		{"too long", []int{0, 1, 2, 3}, "invalid permutation: length 4, want 3"},
+		{"negative index", []int{0, -1, 2}, "invalid permutation: index -1 out of range at position 1"},
+		{"index too large", []int{0, 1, 3}, "invalid permutation: index 3 out of range at position 2"},
+		{"duplicate index", []int{1, 0, 1}, "invalid permutation: duplicate index 1 at position 2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, apply := range map[string]func(*Deck, []int) error{
+				"ApplyPermutation":        (*Deck).ApplyPermutation,
+				"ApplyInversePermutation": (*Deck).ApplyInversePermutation,
+			} {
+				d := &Deck{cards: []Card{NewCard(Ace, Spades), NewCard(Two, Spades), NewCard(Three, Spades)}}
+				before := d.Fingerprint()
+
+				err := apply(d, tt.perm)
+				if err == nil {
+					t.Fatalf("%s(%v) got nil error, want %q", name, tt.perm, tt.wantErr)
+				}
+				if got, want := err.Error(), tt.wantErr; got != want {
+					t.Errorf("%s(%v) error = %q, want %q", name, tt.perm, got, want)
+				}
+				if d.Fingerprint() != before {
+					t.Errorf("After %s(%v) error, deck changed (should be unchanged)", name, tt.perm)
+				}
+			}
+		})
+	}
+}
+
+func TestDeckDrawChecked(t *testing.T) {
+	d := &Deck{cards: []Card{NewCard(Ace, Spades), NewCard(Two, Spades)}}
+
+	card, empty, err := d.DrawChecked()
+	if err != nil {
+		t.Fatalf("DrawChecked() got error: %v, want nil", err)
+	}
+	if card != NewCard(Ace, Spades) || empty {
+		t.Errorf("DrawChecked() = (%v, %v), want (%v, false)", card, empty, NewCard(Ace, Spades))
+	}
+
+	card, empty, err = d.DrawChecked()
+	if err != nil {
+		t.Fatalf("DrawChecked() got error: %v, want nil", err)
+	}
+	if card != NewCard(Two, Spades) || !empty {
+		t.Errorf("DrawChecked() = (%v, %v), want (%v, true)", card, empty, NewCard(Two, Spades))
+	}
+
+	card, empty, err = d.DrawChecked()
+	if err == nil {
+		t.Fatal("DrawChecked() on empty deck got nil error, want error")
+	}
+	if got, want := err.Error(), "cannot draw from empty deck"; got != want {
+		t.Errorf("DrawChecked() error = %q, want %q", got, want)
+	}
+	if card != Card(0) || !empty {
+		t.Errorf("DrawChecked() on empty deck = (%v, %v), want (%v, true)", card, empty, Card(0))
+	}
+}
+
+func BenchmarkDrawChecked(b *testing.B) {
+	for b.Loop() {
+		d := New()
+		for {
+			if _, empty, _ := d.DrawChecked(); empty {
+				break
+			}
+		}
+	}
+}
+
+func TestNewShoe(t *testing.T) {
+	shoe, err := NewShoe(6)
+	if err != nil {
+		t.Fatalf("NewShoe(6) got error: %v, want nil", err)
+	}
+
+	if got, want := shoe.Len(), 312; got != want {
+		t.Errorf("NewShoe(6).Len() = %d, want %d", got, want)
+	}
+	if got, want := shoe.Dealt(), 0; got != want {
+		t.Errorf("NewShoe(6).Dealt() = %d, want %d", got, want)
+	}
+	if got, want := shoe.Penetration(), 0.75; got != want {
+		t.Errorf("NewShoe(6).Penetration() = %v, want %v", got, want)
+	}
+	if shoe.NeedsReshuffle() {
+		t.Error("NewShoe(6).NeedsReshuffle() = true, want false")
+	}
+
+	six, _ := NewMultiple(6)
+	if shoe.deck.Fingerprint() == six.Fingerprint() {
+		t.Error("NewShoe(6) card order unchanged (shoe should be shuffled)")
+	}
+
+	for _, count := range []int{0, -1} {
+		if _, err := NewShoe(count); err == nil {
+			t.Errorf("NewShoe(%d) got nil error, want error", count)
+		}
+	}
+}
+
+func TestShoeCutCard(t *testing.T) {
+	tests := []struct {
+		name        string
+		numDecks    int
+		penetration float64
+		wantCut     int
+	}{
+		{"default six decks", 6, 0.75, 234},
+		{"single deck half", 1, 0.5, 26},
+		{"full penetration", 2, 1, 104},
+		{"rounds up", 1, 0.01, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shoe, _ := NewShoe(tt.numDecks)
+			if err := shoe.SetPenetration(tt.penetration); err != nil {
+				t.Fatalf("SetPenetration(%v) got error: %v, want nil", tt.penetration, err)
+			}
+
+			for i := 0; i < tt.wantCut; i++ {
+				if shoe.NeedsReshuffle() {
+					t.Fatalf("NeedsReshuffle() = true after %d cards, want false before %d", i, tt.wantCut)
+				}
+				if _, err := shoe.Draw(); err != nil {
+					t.Fatalf("Draw() got error: %v, want nil", err)
+				}
+			}
+
+			if !shoe.NeedsReshuffle() {
+				t.Errorf("NeedsReshuffle() = false after %d cards, want true", tt.wantCut)
+			}
+			if got, want := shoe.Dealt(), tt.wantCut; got != want {
+				t.Errorf("Dealt() = %d, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestShoeDrawPastCutCard(t *testing.T) {
+	shoe, _ := NewShoe(1)
+	_ = shoe.SetPenetration(0.5)
+
+	for i := 0; i < 52; i++ {
+		if _, err := shoe.Draw(); err != nil {
+			t.Fatalf("Draw() #%d got error: %v, want nil", i+1, err)
+		}
+	}
+
+	_, err := shoe.Draw()
+	if err == nil {
+		t.Fatal("Draw() from empty shoe got nil error, want error")
+	}
+	if got, want := err.Error(), "cannot draw from empty shoe"; got != want {
+		t.Errorf("Draw() error = %q, want %q", got, want)
+	}
+	if !errors.Is(err, ErrInsufficientCards) {
+		t.Errorf("Draw() error = %v, want ErrInsufficientCards", err)
+	}
+	if got, want := shoe.Dealt(), 52; got != want {
+		t.Errorf("After failed Draw(), Dealt() = %d, want %d", got, want)
+	}
+}
+
+func TestShoeSetPenetrationErrors(t *testing.T) {
+	for _, p := range []float64{0, -0.5, 1.01, math.NaN(), math.Inf(1)} {
+		shoe, _ := NewShoe(1)
+		err := shoe.SetPenetration(p)
+		if err == nil {
+			t.Errorf("SetPenetration(%v) got nil error, want error", p)
+			continue
+		}
+		if got, want := err.Error(), fmt.Sprintf("penetration must be in the range (0, 1], got %v", p); got != want {
+			t.Errorf("SetPenetration(%v) error = %q, want %q", p, got, want)
+		}
+		if got, want := shoe.Penetration(), 0.75; got != want {
+			t.Errorf("After SetPenetration(%v) error, Penetration() = %v, want %v (unchanged)", p, got, want)
+		}
+	}
+}
+
+func TestShoeReshuffle(t *testing.T) {
+	shoe, _ := NewShoe(2)
+	for !shoe.NeedsReshuffle() {
+		_, _ = shoe.Draw()
+	}
+
+	shoe.ReshuffleWith(NewSeededShuffler(3))
+
+	if got, want := shoe.Len(), 104; got != want {
+		t.Errorf("After ReshuffleWith(), Len() = %d, want %d", got, want)
+	}
+	if got, want := shoe.Dealt(), 0; got != want {
+		t.Errorf("After ReshuffleWith(), Dealt() = %d, want %d", got, want)
+	}
+	if shoe.NeedsReshuffle() {
+		t.Error("After ReshuffleWith(), NeedsReshuffle() = true, want false")
+	}
+
+	want, _ := NewMultiple(2)
+	want.ShuffleWithSeed(3)
+	if got, want := shoe.deck.Fingerprint(), want.Fingerprint(); got != want {
+		t.Errorf("After ReshuffleWith(seeded), fingerprint = %#x, want %#x", got, want)
+	}
+
+	shoe.Reshuffle()
+	if got, want := shoe.Len(), 104; got != want {
+		t.Errorf("After Reshuffle(), Len() = %d, want %d", got, want)
+	}
+}
+
+func TestDealInto(t *testing.T) {
+	d := New()
+	originalCards := d.Cards()
+	hands := [][]Card{make([]Card, 2), make([]Card, 3), make([]Card, 1)}
+
+	if err := d.DealInto(hands); err != nil {
+		t.Fatalf("DealInto() got error: %v, want nil", err)
+	}
+
+	i := 0
+	for h, hand := range hands {
+		for c, card := range hand {
+			if got, want := card, originalCards[i]; got != want {
+				t.Errorf("DealInto() hands[%d][%d] = %v, want %v", h, c, got, want)
+			}
+			i++
+		}
+	}
+
+	if got, want := d.Len(), 46; got != want {
+		t.Errorf("After DealInto(), deck.Len() = %d, want %d", got, want)
+	}
+
+	// Reusing the hands overwrites their contents
+	if err := d.DealInto(hands); err != nil {
+		t.Fatalf("second DealInto() got error: %v, want nil", err)
+	}
+	if got, want := hands[0][0], originalCards[6]; got != want {
+		t.Errorf("second DealInto() hands[0][0] = %v, want %v", got, want)
+	}
+}
+
+func TestDealIntoEquivalentToDealHands(t *testing.T) {
+	d1 := New()
+	d1.ShuffleWithSeed(8)
+	d2 := New()
+	d2.ShuffleWithSeed(8)
+
+	want, _ := d1.DealHands([]int{5, 5, 3})
+	hands := [][]Card{make([]Card, 5), make([]Card, 5), make([]Card, 3)}
+	if err := d2.DealInto(hands); err != nil {
+		t.Fatalf("DealInto() got error: %v, want nil", err)
+	}
+
+	for h := range want {
+		for c := range want[h] {
+			if hands[h][c] != want[h][c] {
+				t.Errorf("DealInto() hands[%d][%d] = %v, want %v (same as DealHands)", h, c, hands[h][c], want[h][c])
+			}
+		}
+	}
+	if got, want := d2.Fingerprint(), d1.Fingerprint(); got != want {
+		t.Errorf("After DealInto(), remaining deck differs from DealHands()")
+	}
+}
+
+func TestDealIntoValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		hands    [][]Card
+		deckSize int
+		wantErr  string
+	}{
+		{"no hands", nil, 52, "hands must contain at least one hand"},
+		{"empty hand", [][]Card{make([]Card, 2), {}}, 52, "hand size must be positive: got 0 at index 1"},
+		{"oversized hand", [][]Card{make([]Card, 53)}, 52, "hand size (53) at index 0 exceeds deck size of 52"},
+		{"insufficient cards", [][]Card{make([]Card, 3), make([]Card, 3)}, 5, "insufficient cards: need 6, have 5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, _ := NewMultiple(2)
+			for d.Len() > tt.deckSize {
+				_, _ = d.Draw()
+			}
+			before := d.Fingerprint()
+
+			err := d.DealInto(tt.hands)
+			if err == nil {
+				t.Fatalf("DealInto() got nil error, want %q", tt.wantErr)
+			}
+			if got, want := err.Error(), tt.wantErr; got != want {
+				t.Errorf("DealInto() error = %q, want %q", got, want)
+			}
+			if d.Fingerprint() != before || d.Len() != tt.deckSize {
+				t.Errorf("After DealInto() error, deck changed (should be unchanged)")
+			}
+			for h, hand := range tt.hands {
+				for c, card := range hand {
+					if card != Card(0) {
+						t.Errorf("After DealInto() error, hands[%d][%d] = %v, want unchanged zero card", h, c, card)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestDealIntoAllocs(t *testing.T) {
+	d := New()
+	snap := d.Snapshot()
+	hands := [][]Card{make([]Card, 2), make([]Card, 2), make([]Card, 2)}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = d.Restore(snap)
+		_ = d.DealInto(hands)
+	})
+	if allocs != 0 {
+		t.Errorf("DealInto() allocated %v times per run, want 0", allocs)
+	}
+}
+
+func BenchmarkDealInto(b *testing.B) {
+	d := New()
+	snap := d.Snapshot()
+	hands := [][]Card{make([]Card, 5), make([]Card, 5), make([]Card, 5), make([]Card, 5)}
+
+	for b.Loop() {
+		_ = d.Restore(snap)
+		_ = d.DealInto(hands)
+	}
+}
+
+func TestDeckSameMultiset(t *testing.T) {
+	shuffled := New()
+	shuffled.ShuffleWithSeed(4)
+
+	twoDecks, _ := NewMultiple(2)
+	twoDecksShuffled, _ := NewMultiple(2)
+	twoDecksShuffled.ShuffleWithSeed(9)
+
+	duplicated := New()
+	_, _ = duplicated.Draw()
+	duplicated.Add(NewCard(King, Clubs))
+
+	tests := []struct {
+		name string
+		a, b *Deck
+		want bool
+	}{
+		{"identical", New(), New(), true},
+		{"shuffled", New(), shuffled, true},
+		{"multi-deck shuffled", twoDecks, twoDecksShuffled, true},
+		{"both empty", &Deck{}, &Deck{}, true},
+		{"different lengths", New(), NewWithJokers(), false},
+		{"card replaced by duplicate", New(), duplicated, false},
+		{"single vs double deck", New(), twoDecks, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.SameMultiset(tt.b); got != tt.want {
+				t.Errorf("a.SameMultiset(b) = %v, want %v", got, tt.want)
+			}
+			if got := tt.b.SameMultiset(tt.a); got != tt.want {
+				t.Errorf("b.SameMultiset(a) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeckOperationsPreserveMultiset(t *testing.T) {
+	d := New()
+	d.ShuffleWithSeed(1)
+	hand, _ := d.DrawN(5)
+	d.CycleN(7)
+	_ = d.Swap(3, 30)
+	for _, card := range hand {
+		d.AddToTop(card)
+	}
+	d.ShuffleTracked(NewSeededShuffler(2))
+	d.Sort()
+
+	if !d.SameMultiset(New()) {
+		t.Error("After a sequence of operations, deck.SameMultiset(New()) = false, want true")
+	}
+}
+
+func TestDeckRemoveSuit(t *testing.T) {
+	tests := []struct {
+		name        string
+		deck        func() *Deck
+		suit        Suit
+		wantRemoved int
+		wantLen     int
+	}{
+		{"hearts from standard deck", New, Hearts, 13, 39},
+		{"spades keeps black joker", NewWithJokers, Spades, 13, 41},
+		{"hearts keeps red joker", NewWithJokers, Hearts, 13, 41},
+		{"clubs from two decks", func() *Deck { d, _ := NewMultiple(2); return d }, Clubs, 26, 78},
+		{"empty deck", func() *Deck { return &Deck{} }, Diamonds, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := tt.deck()
+			removed := d.RemoveSuit(tt.suit)
+
+			if got, want := removed, tt.wantRemoved; got != want {
+				t.Errorf("RemoveSuit(%v) = %d, want %d", tt.suit, got, want)
+			}
+			if got, want := d.Len(), tt.wantLen; got != want {
+				t.Errorf("After RemoveSuit(%v), deck.Len() = %d, want %d", tt.suit, got, want)
+			}
+			for _, card := range d.Cards() {
+				if !card.IsJoker() && card.Suit() == tt.suit {
+					t.Errorf("After RemoveSuit(%v), deck still contains %v", tt.suit, card)
+				}
+			}
+		})
+	}
+}
+
+func TestDeckRemoveRank(t *testing.T) {
+	tests := []struct {
+		name        string
+		deck        func() *Deck
+		rank        Rank
+		wantRemoved int
+		wantLen     int
+	}{
+		{"aces from standard deck", New, Ace, 4, 48},
+		{"kings from deck with jokers", NewWithJokers, King, 4, 50},
+		{"red joker", NewWithJokers, RedJoker, 1, 53},
+		{"joker absent", New, BlackJoker, 0, 52},
+		{"twos from two decks", func() *Deck { d, _ := NewMultiple(2); return d }, Two, 8, 96},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := tt.deck()
+			removed := d.RemoveRank(tt.rank)
+
+			if got, want := removed, tt.wantRemoved; got != want {
+				t.Errorf("RemoveRank(%v) = %d, want %d", tt.rank, got, want)
+			}
+			if got, want := d.Len(), tt.wantLen; got != want {
+				t.Errorf("After RemoveRank(%v), deck.Len() = %d, want %d", tt.rank, got, want)
+			}
+			for _, card := range d.Cards() {
+				if card.Rank() == tt.rank {
+					t.Errorf("After RemoveRank(%v), deck still contains %v", tt.rank, card)
+				}
+			}
+		})
+	}
+}
+
+func TestDeckRemoveRankPreservesOrder(t *testing.T) {
+	d := New()
+	d.RemoveRank(Ace)
+
+	want := New().Filter(func(c Card) bool { return c.Rank() != Ace })
+	if got, want := d.Fingerprint(), want.Fingerprint(); got != want {
+		t.Errorf("After RemoveRank(Ace), fingerprint = %#x, want %#x (order should be preserved)", got, want)
+	}
+}
+
+func TestDeckBurnAndDraw(t *testing.T) {
+	tests := []struct {
+		name       string
+		burn, draw int
+	}{
+		{"flop", 1, 3},
+		{"turn", 1, 1},
+		{"no burn", 0, 5},
+		{"burn only", 2, 0},
+		{"whole deck", 2, 50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := New()
+			originalCards := d.Cards()
+
+			burned, drawn, err := d.BurnAndDraw(tt.burn, tt.draw)
+			if err != nil {
+				t.Fatalf("BurnAndDraw(%d, %d) got error: %v, want nil", tt.burn, tt.draw, err)
+			}
+
+			if got, want := len(burned), tt.burn; got != want {
+				t.Fatalf("BurnAndDraw(%d, %d) burned %d cards, want %d", tt.burn, tt.draw, got, want)
+			}
+			if got, want := len(drawn), tt.draw; got != want {
+				t.Fatalf("BurnAndDraw(%d, %d) drew %d cards, want %d", tt.burn, tt.draw, got, want)
+			}
+			for i, card := range burned {
+				if got, want := card, originalCards[i]; got != want {
+					t.Errorf("BurnAndDraw(%d, %d) burned[%d] = %v, want %v", tt.burn, tt.draw, i, got, want)
+				}
+			}
+			for i, card := range drawn {
+				if got, want := card, originalCards[tt.burn+i]; got != want {
+					t.Errorf("BurnAndDraw(%d, %d) drawn[%d] = %v, want %v", tt.burn, tt.draw, i, got, want)
+				}
+			}
+			if got, want := d.Len(), 52-tt.burn-tt.draw; got != want {
+				t.Errorf("After BurnAndDraw(%d, %d), deck.Len() = %d, want %d", tt.burn, tt.draw, got, want)
+			}
+		})
+	}
+}
+
+func TestDeckBurnAndDrawErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		burn, draw int
+		wantErr    string
+	}{
+		{"negative burn", -1, 3, "cannot burn negative number of cards: -1"},
+		{"negative draw", 1, -3, "cannot draw negative number of cards: -3"},
+		{"not enough for draw", 1, 52, "not enough cards in deck: have 52, need 53"},
+		{"not enough for burn", 53, 0, "not enough cards in deck: have 52, need 53"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := New()
+			burned, drawn, err := d.BurnAndDraw(tt.burn, tt.draw)
+			if err == nil {
+				t.Fatalf("BurnAndDraw(%d, %d) got nil error, want %q", tt.burn, tt.draw, tt.wantErr)
+			}
+			if got, want := err.Error(), tt.wantErr; got != want {
+				t.Errorf("BurnAndDraw(%d, %d) error = %q, want %q", tt.burn, tt.draw, got, want)
+			}
+			if burned != nil || drawn != nil {
+				t.Errorf("BurnAndDraw(%d, %d) = (%v, %v), want nil slices when error occurs", tt.burn, tt.draw, burned, drawn)
+			}
+			if got, want := d.Len(), 52; got != want {
+				t.Errorf("After BurnAndDraw() error, deck.Len() = %d, want %d (deck should be unchanged)", got, want)
+			}
+		})
+	}
+}
+
+func TestCardCompare(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Card
+		want int
+	}{
+		{"same card", NewCard(Ace, Spades), NewCard(Ace, Spades), 0},
+		{"rank within suit", NewCard(Two, Hearts), NewCard(King, Hearts), -1},
+		{"suit before rank", NewCard(King, Spades), NewCard(Ace, Hearts), -1},
+		{"later suit", NewCard(Ace, Clubs), NewCard(King, Diamonds), 1},
+		{"regular before red joker", NewCard(King, Clubs), NewRedJoker(), -1},
+		{"black joker after regular", NewBlackJoker(), NewCard(Ace, Spades), 1},
+		{"red before black joker", NewRedJoker(), NewBlackJoker(), -1},
+		{"black after red joker", NewBlackJoker(), NewRedJoker(), 1},
+		{"same joker", NewRedJoker(), NewRedJoker(), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Compare(tt.b); got != tt.want {
+				t.Errorf("%v.Compare(%v) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+			if got := tt.b.Compare(tt.a); got != -tt.want {
+				t.Errorf("%v.Compare(%v) = %d, want %d", tt.b, tt.a, got, -tt.want)
+			}
+		})
+	}
+}
+
+func TestCardCompareSortFunc(t *testing.T) {
+	d := NewWithJokers()
+	d.ShuffleWithSeed(13)
+	hand := d.Cards()
+
+	slices.SortFunc(hand, Card.Compare)
+
+	want := NewWithJokers().Cards()
+	for i := range want {
+		if hand[i] != want[i] {
+			t.Errorf("After slices.SortFunc(hand, Card.Compare), hand[%d] = %v, want %v", i, hand[i], want[i])
+		}
+	}
+}
+
+func TestDeckPeekOr(t *testing.T) {
+	fallback := NewBlackJoker()
+
+	tests := []struct {
+		name       string
+		deck       *Deck
+		wantTop    Card
+		wantBottom Card
+	}{
+		{"standard deck", New(), NewCard(Ace, Spades), NewCard(King, Clubs)},
+		{"single card", &Deck{cards: []Card{NewCard(Five, Hearts)}}, NewCard(Five, Hearts), NewCard(Five, Hearts)},
+		{"empty deck", &Deck{}, fallback, fallback},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			originalLen := tt.deck.Len()
+
+			if got := tt.deck.PeekOr(fallback); got != tt.wantTop {
+				t.Errorf("PeekOr(%v) = %v, want %v", fallback, got, tt.wantTop)
+			}
+			if got := tt.deck.PeekBottomOr(fallback); got != tt.wantBottom {
+				t.Errorf("PeekBottomOr(%v) = %v, want %v", fallback, got, tt.wantBottom)
+			}
+			if got, want := tt.deck.Len(), originalLen; got != want {
+				t.Errorf("After PeekOr(), deck.Len() = %d, want %d (deck should be unchanged)", got, want)
+			}
+		})
+	}
+}
+
+func TestParseCard(t *testing.T) {
+	for _, card := range NewWithJokers().Cards() {
+		got, err := ParseCard(card.ShortString())
+		if err != nil {
+			t.Errorf("ParseCard(%q) got error: %v, want nil", card.ShortString(), err)
+			continue
+		}
+		if got != card {
+			t.Errorf("ParseCard(%q) = %v, want %v", card.ShortString(), got, card)
+		}
+	}
+}
+
+func TestParseCardErrors(t *testing.T) {
+	for _, s := range []string{"", "♠", "Ace", "1♠", "11♥", "A♠", "Ace of Spades", "ace♠", "JKX", " Ace♠", "Ace♠♠"} {
+		card, err := ParseCard(s)
+		if err == nil {
+			t.Errorf("ParseCard(%q) = %v, want error", s, card)
+			continue
+		}
+		if got, want := err.Error(), fmt.Sprintf("invalid card: %q", s); got != want {
+			t.Errorf("ParseCard(%q) error = %q, want %q", s, got, want)
+		}
+	}
+}
+
+func TestDeckMarshalText(t *testing.T) {
+	d := &Deck{cards: []Card{NewCard(Ace, Spades), NewCard(Ten, Diamonds), NewRedJoker(), NewCard(Ace, Spades), NewBlackJoker()}}
+
+	text, err := d.MarshalText()
+	if err != nil {
+		t.Fatalf("MarshalText() got error: %v, want nil", err)
+	}
+	if got, want := string(text), "Ace♠ 10♦ JKR Ace♠ JKB"; got != want {
+		t.Errorf("MarshalText() = %q, want %q", got, want)
+	}
+
+	text, err = (&Deck{}).MarshalText()
+	if err != nil {
+		t.Fatalf("MarshalText() of empty deck got error: %v, want nil", err)
+	}
+	if got, want := string(text), ""; got != want {
+		t.Errorf("MarshalText() of empty deck = %q, want %q", got, want)
+	}
+
+	_, err = (&Deck{cards: []Card{NewCard(Ace, Spades), Card(0)}}).MarshalText()
+	if err == nil {
+		t.Fatal("MarshalText() with invalid card got nil error, want error")
+	}
+	if got, want := err.Error(), "invalid card at index 1: 0x00"; got != want {
+		t.Errorf("MarshalText() error = %q, want %q", got, want)
+	}
+}
+
+func TestDeckTextRoundTrip(t *testing.T) {
+	shoe, _ := NewMultipleWithJokers(2)
+	shoe.ShuffleWithSeed(21)
+
+	for _, d := range []*Deck{{}, New(), shoe} {
+		text, err := d.MarshalText()
+		if err != nil {
+			t.Fatalf("MarshalText() got error: %v, want nil", err)
+		}
+
+		decoded := New()
+		if err := decoded.UnmarshalText(text); err != nil {
+			t.Fatalf("UnmarshalText(%q) got error: %v, want nil", text, err)
+		}
+		if got, want := decoded.Checksum(), d.Checksum(); got != want || decoded.Len() != d.Len() {
+			t.Errorf("Text round trip of %d cards produced a different deck", d.Len())
+		}
+	}
+}
+
+func TestDeckUnmarshalText(t *testing.T) {
+	d := &Deck{}
+	if err := d.UnmarshalText([]byte("  Ace♠\n10♦\tJKB ")); err != nil {
+		t.Fatalf("UnmarshalText() got error: %v, want nil", err)
+	}
+
+	want := []Card{NewCard(Ace, Spades), NewCard(Ten, Diamonds), NewBlackJoker()}
+	if got := d.Cards(); len(got) != len(want) {
+		t.Fatalf("UnmarshalText() = %v, want %v", got, want)
+	}
+	for i, card := range d.Cards() {
+		if card != want[i] {
+			t.Errorf("UnmarshalText() card[%d] = %v, want %v", i, card, want[i])
+		}
+	}
+}
+
+func TestDeckUnmarshalTextErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		wantErr string
+	}{
+		{"unknown token", "Ace♠ Ace", `invalid data: card 1: invalid card: "Ace"`},
+		{"comma separated", "Ace♠,2♠", `invalid data: card 0: invalid card: "Ace♠,2♠"`},
+		{"long form", "Ace of Spades", `invalid data: card 0: invalid card: "Ace"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := New()
+			err := d.UnmarshalText([]byte(tt.text))
+			if err == nil {
+				t.Fatalf("UnmarshalText(%q) got nil error, want %q", tt.text, tt.wantErr)
+			}
+			if got, want := err.Error(), tt.wantErr; got != want {
+				t.Errorf("UnmarshalText(%q) error = %q, want %q", tt.text, got, want)
+			}
+			if got, want := d.Len(), 52; got != want {
+				t.Errorf("After UnmarshalText() error, deck.Len() = %d, want %d (deck should be unchanged)", got, want)
+			}
+		})
+	}
+}
+
+func TestSplitDeal(t *testing.T) {
+	d := New()
+	d.ShuffleWithSeed(17)
+	before := d.Cards()
+
+	hands, remainder, err := d.SplitDeal(4, 5)
+	if err != nil {
+		t.Fatalf("SplitDeal(4, 5) got error: %v, want nil", err)
+	}
+
+	// The receiver is untouched
+	if got, want := d.Len(), 52; got != want {
+		t.Errorf("After SplitDeal(4, 5), deck.Len() = %d, want %d", got, want)
+	}
+	for i, card := range d.Cards() {
+		if card != before[i] {
+			t.Errorf("After SplitDeal(4, 5), card[%d] = %v, want %v (receiver should be unchanged)", i, card, before[i])
+		}
+	}
+
+	// Hands match what Deal would produce
+	dealt := New()
+	dealt.ShuffleWithSeed(17)
+	want, _ := dealt.Deal(4, 5)
+	for h := range want {
+		for c := range want[h] {
+			if hands[h][c] != want[h][c] {
+				t.Errorf("SplitDeal(4, 5)[%d][%d] = %v, want %v", h, c, hands[h][c], want[h][c])
+			}
+		}
+	}
+
+	if got, want := remainder.Len(), 32; got != want {
+		t.Fatalf("SplitDeal(4, 5) remainder.Len() = %d, want %d", got, want)
+	}
+	if got, want := remainder.Fingerprint(), dealt.Fingerprint(); got != want {
+		t.Errorf("SplitDeal(4, 5) remainder fingerprint = %#x, want %#x", got, want)
+	}
+
+	// Results are independent of the receiver
+	hands[0][0] = NewRedJoker()
+	_ = remainder.Swap(0, 1)
+	remainder.Add(NewBlackJoker())
+	for i, card := range d.Cards() {
+		if card != before[i] {
+			t.Fatalf("After modifying SplitDeal results, receiver card[%d] = %v, want %v", i, card, before[i])
+		}
+	}
+}
+
+func TestSplitDealValidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		numPlayers int
+		cardsEach  int
+		wantErr    string
+	}{
+		{"zero players", 0, 5, "number of players must be at least 1"},
+		{"zero cards each", 4, 0, "cards per player must be at least 1"},
+		{"insufficient cards", 4, 14, "insufficient cards: need 56, have 52"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := New()
+			hands, remainder, err := d.SplitDeal(tt.numPlayers, tt.cardsEach)
+			if err == nil {
+				t.Fatalf("SplitDeal(%d, %d) got nil error, want %q", tt.numPlayers, tt.cardsEach, tt.wantErr)
+			}
+			if got, want := err.Error(), tt.wantErr; got != want {
+				t.Errorf("SplitDeal(%d, %d) error = %q, want %q", tt.numPlayers, tt.cardsEach, got, want)
+			}
+			if hands != nil || remainder != nil {
+				t.Errorf("SplitDeal(%d, %d) = (%v, %v), want nil results when error occurs", tt.numPlayers, tt.cardsEach, hands, remainder)
+			}
+		})
+	}
+}
+
+func TestStableShufflerGenerator(t *testing.T) {
+	// Reference splitmix64 outputs for seed 0
+	want := []uint64{0xe220a8397b1dcdaf, 0x6e789e6aa1b965f4, 0x06c45d188009454f}
+
+	s := NewStableShuffler(0)
+	for i, w := range want {
+		if got := s.next(); got != w {
+			t.Errorf("next() call %d = %#x, want %#x", i, got, w)
+		}
+	}
+}
+
+func TestStableShuffler(t *testing.T) {
+	// These values are part of the StableShuffler contract and must never change
+	d := New()
+	d.ShuffleWith(NewStableShuffler(42))
+
+	want := []Card{
+		NewCard(Jack, Clubs),
+		NewCard(Five, Spades),
+		NewCard(Six, Hearts),
+		NewCard(Three, Clubs),
+		NewCard(Four, Clubs),
+		NewCard(Ace, Spades),
+		NewCard(Ten, Spades),
+		NewCard(Six, Spades),
+	}
+	for i, card := range want {
+		if got := d.Cards()[i]; got != card {
+			t.Errorf("After ShuffleWith(NewStableShuffler(42)), card[%d] = %v, want %v", i, got, card)
+		}
+	}
+
+	other := New()
+	other.ShuffleWith(NewStableShuffler(42))
+	if got, want := other.Fingerprint(), d.Fingerprint(); got != want {
+		t.Errorf("Same seed produced fingerprint %#x, want %#x", got, want)
+	}
+
+	other = New()
+	other.ShuffleWith(NewStableShuffler(43))
+	if other.Fingerprint() == d.Fingerprint() {
+		t.Error("Different seeds produced identical shuffles")
+	}
+
+	if !d.SameMultiset(New()) {
+		t.Error("StableShuffler lost or duplicated cards")
+	}
+}
+
+func TestAnyAll(t *testing.T) {
+	isAce := func(c Card) bool { return c.Rank() == Ace }
+	notJoker := func(c Card) bool { return !c.IsJoker() }
+
+	tests := []struct {
+		name    string
+		deck    *Deck
+		pred    func(Card) bool
+		wantAny bool
+		wantAll bool
+	}{
+		{"empty deck", &Deck{}, isAce, false, true},
+		{"aces in full deck", New(), isAce, true, false},
+		{"no jokers in full deck", New(), notJoker, true, true},
+		{"jokers present", NewWithJokers(), notJoker, true, false},
+		{"aces removed", New().Filter(func(c Card) bool { return !isAce(c) }), isAce, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.deck.Any(tt.pred); got != tt.wantAny {
+				t.Errorf("Any() = %v, want %v", got, tt.wantAny)
+			}
+			if got := tt.deck.All(tt.pred); got != tt.wantAll {
+				t.Errorf("All() = %v, want %v", got, tt.wantAll)
+			}
+		})
+	}
+}
+
+func TestAnyAllShortCircuit(t *testing.T) {
+	d := New() // Ace of Spades is on top
+
+	calls := 0
+	d.Any(func(c Card) bool {
+		calls++
+		return c.Rank() == Ace
+	})
+	if got, want := calls, 1; got != want {
+		t.Errorf("Any() called predicate %d times, want %d", got, want)
+	}
+
+	calls = 0
+	d.All(func(c Card) bool {
+		calls++
+		return c.Rank() != Ace
+	})
+	if got, want := calls, 1; got != want {
+		t.Errorf("All() called predicate %d times, want %d", got, want)
+	}
+}
+
+func TestDealNoJokers(t *testing.T) {
+	d := NewWithJokers()
+	// Move the jokers to the top so they must be skipped
+	d.CycleN(-2)
+
+	hands, err := d.DealNoJokers(4, 5)
+	if err != nil {
+		t.Fatalf("DealNoJokers(4, 5) got error: %v, want nil", err)
+	}
+
+	if got, want := len(hands), 4; got != want {
+		t.Fatalf("DealNoJokers(4, 5) returned %d hands, want %d", got, want)
+	}
+	for i, hand := range hands {
+		if got, want := len(hand), 5; got != want {
+			t.Errorf("DealNoJokers(4, 5) hand %d has %d cards, want %d", i, got, want)
+		}
+		for _, card := range hand {
+			if card.IsJoker() {
+				t.Errorf("DealNoJokers(4, 5) hand %d contains %v", i, card)
+			}
+		}
+	}
+
+	// Hands are dealt in sequential blocks, like Deal
+	if got, want := hands[0][0], NewCard(Ace, Spades); got != want {
+		t.Errorf("DealNoJokers(4, 5)[0][0] = %v, want %v", got, want)
+	}
+	if got, want := hands[1][0], NewCard(Six, Spades); got != want {
+		t.Errorf("DealNoJokers(4, 5)[1][0] = %v, want %v", got, want)
+	}
+
+	// Skipped jokers remain on top of the undealt cards
+	if got, want := d.Len(), 34; got != want {
+		t.Fatalf("After DealNoJokers(4, 5), deck.Len() = %d, want %d", got, want)
+	}
+	cards := d.Cards()
+	if !cards[0].IsJoker() || !cards[1].IsJoker() {
+		t.Errorf("After DealNoJokers(4, 5), top cards = %v, %v, want jokers", cards[0], cards[1])
+	}
+	if got, want := cards[2], NewCard(Eight, Hearts); got != want {
+		t.Errorf("After DealNoJokers(4, 5), card[2] = %v, want %v", got, want)
+	}
+
+	// Appending to one hand must not affect the next
+	hands[0] = append(hands[0], NewRedJoker())
+	if got, want := hands[1][0], NewCard(Six, Spades); got != want {
+		t.Errorf("After appending to hand 0, hands[1][0] = %v, want %v", got, want)
+	}
+}
+
+func TestDealNoJokersAllRegularCards(t *testing.T) {
+	d := NewWithJokers()
+	d.ShuffleWithSeed(3)
+
+	hands, err := d.DealNoJokers(4, 13)
+	if err != nil {
+		t.Fatalf("DealNoJokers(4, 13) got error: %v, want nil", err)
+	}
+
+	var dealt []Card
+	for _, hand := range hands {
+		dealt = append(dealt, hand...)
+	}
+	if !New().SameMultiset(&Deck{cards: dealt}) {
+		t.Error("DealNoJokers(4, 13) did not deal exactly the 52 regular cards")
+	}
+	if got, want := d.Len(), 2; got != want {
+		t.Fatalf("After DealNoJokers(4, 13), deck.Len() = %d, want %d", got, want)
+	}
+	if !d.All(Card.IsJoker) {
+		t.Errorf("After DealNoJokers(4, 13), deck = %v, want only jokers", d)
+	}
+}
+
+func TestDealNoJokersValidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		numPlayers int
+		cardsEach  int
+		wantErr    string
+	}{
+		{"zero players", 0, 5, "number of players must be at least 1"},
+		{"zero cards each", 4, 0, "cards per player must be at least 1"},
+		{"insufficient cards", 5, 11, "insufficient cards: need 55, have 54"},
+		{"jokers would be needed", 6, 9, "insufficient non-joker cards: need 54, have 52"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewWithJokers()
+			before := d.Cards()
+
+			hands, err := d.DealNoJokers(tt.numPlayers, tt.cardsEach)
+			if err == nil {
+				t.Fatalf("DealNoJokers(%d, %d) got nil error, want %q", tt.numPlayers, tt.cardsEach, tt.wantErr)
+			}
+			if got, want := err.Error(), tt.wantErr; got != want {
+				t.Errorf("DealNoJokers(%d, %d) error = %q, want %q", tt.numPlayers, tt.cardsEach, got, want)
+			}
+			if hands != nil {
+				t.Errorf("DealNoJokers(%d, %d) = %v, want nil hands when error occurs", tt.numPlayers, tt.cardsEach, hands)
+			}
+			if !slices.Equal(d.Cards(), before) {
+				t.Errorf("DealNoJokers(%d, %d) modified the deck on error", tt.numPlayers, tt.cardsEach)
+			}
+		})
+	}
+}
+
+func TestNewAnnotatedDeck(t *testing.T) {
+	cards := []Card{NewCard(Ace, Spades), NewCard(Ace, Spades), NewCard(King, Hearts)}
+	values := []string{"first", "second", "third"}
+
+	ad, err := NewAnnotatedDeck(cards, values)
+	if err != nil {
+		t.Fatalf("NewAnnotatedDeck() got error: %v, want nil", err)
+	}
+	if got, want := ad.Len(), 3; got != want {
+		t.Errorf("Len() = %d, want %d", got, want)
+	}
+
+	// Inputs are copied
+	cards[0] = NewRedJoker()
+	values[0] = "changed"
+	if got, want := ad.Cards()[0], NewCard(Ace, Spades); got != want {
+		t.Errorf("Cards()[0] = %v, want %v", got, want)
+	}
+	if got, want := ad.Values()[0], "first"; got != want {
+		t.Errorf("Values()[0] = %q, want %q", got, want)
+	}
+
+	_, err = NewAnnotatedDeck([]Card{NewCard(Ace, Spades)}, []int{1, 2})
+	if err == nil {
+		t.Fatal("NewAnnotatedDeck() with mismatched lengths got nil error")
+	}
+	if got, want := err.Error(), "cards and values must have the same length: 1 and 2"; got != want {
+		t.Errorf("NewAnnotatedDeck() error = %q, want %q", got, want)
+	}
+}
+
+func TestAnnotatedDeckDraw(t *testing.T) {
+	ad, _ := NewAnnotatedDeck([]Card{NewCard(Two, Clubs), NewCard(Two, Clubs)}, []int{10, 20})
+	ad.Add(NewCard(Three, Clubs), 30)
+
+	for _, want := range []struct {
+		card  Card
+		value int
+	}{
+		{NewCard(Two, Clubs), 10},
+		{NewCard(Two, Clubs), 20},
+		{NewCard(Three, Clubs), 30},
+	} {
+		card, value, err := ad.Draw()
+		if err != nil {
+			t.Fatalf("Draw() got error: %v, want nil", err)
+		}
+		if card != want.card || value != want.value {
+			t.Errorf("Draw() = (%v, %d), want (%v, %d)", card, value, want.card, want.value)
+		}
+	}
+
+	card, value, err := ad.Draw()
+	if err == nil {
+		t.Fatal("Draw() on empty deck got nil error")
+	}
+	if card != Card(0) || value != 0 {
+		t.Errorf("Draw() on empty deck = (%v, %d), want zero values", card, value)
+	}
+}
+
+func TestAnnotatedDeckShuffleKeepsAlignment(t *testing.T) {
+	d := New()
+	values := make([]int, d.Len())
+	for i, c := range d.Cards() {
+		values[i] = c.Ordinal()
+	}
+	ad, _ := NewAnnotatedDeck(d.Cards(), values)
+
+	ad.ShuffleWith(NewSeededShuffler(11))
+	ad.SecureShuffle()
+
+	cards := ad.Cards()
+	if slices.Equal(cards, d.Cards()) {
+		t.Error("ShuffleWith() did not change the order of the cards")
+	}
+	for i, v := range ad.Values() {
+		if got, want := cards[i].Ordinal(), v; got != want {
+			t.Errorf("After shuffle, card[%d] = %v with value %d, want value %d", i, cards[i], v, got)
+		}
+	}
+}
+
+func TestAnnotatedDeckDeal(t *testing.T) {
+	d := New()
+	values := make([]int, d.Len())
+	for i := range values {
+		values[i] = i * 100
+	}
+	ad, _ := NewAnnotatedDeck(d.Cards(), values)
+
+	hands, err := ad.Deal(4, 5)
+	if err != nil {
+		t.Fatalf("Deal(4, 5) got error: %v, want nil", err)
+	}
+	want, _ := New().Deal(4, 5)
+	for p := range want {
+		for i := range want[p] {
+			got := hands[p][i]
+			if got.Card != want[p][i] || got.Value != (p*5+i)*100 {
+				t.Errorf("Deal(4, 5)[%d][%d] = %v, want {%v %d}", p, i, got, want[p][i], (p*5+i)*100)
+			}
+		}
+	}
+
+	if got, want := ad.Len(), 32; got != want {
+		t.Errorf("After Deal(4, 5), Len() = %d, want %d", got, want)
+	}
+	card, value, _ := ad.Draw()
+	if got, want := value, 2000; got != want {
+		t.Errorf("After Deal(4, 5), Draw() = (%v, %d), want value %d", card, got, want)
+	}
+
+	_, err = ad.Deal(4, 8)
+	if err == nil {
+		t.Fatal("Deal(4, 8) got nil error, want error")
+	}
+	if got, want := err.Error(), "insufficient cards: need 32, have 31"; got != want {
+		t.Errorf("Deal(4, 8) error = %q, want %q", got, want)
+	}
+	if got, want := ad.Len(), 31; got != want {
+		t.Errorf("After failed Deal, Len() = %d, want %d", got, want)
+	}
+}
+
+func TestShuffleRange(t *testing.T) {
+	d := New()
+	before := d.Cards()
+
+	if err := d.ShuffleRange(10, 30, NewSeededShuffler(5)); err != nil {
+		t.Fatalf("ShuffleRange(10, 30) got error: %v, want nil", err)
+	}
+
+	after := d.Cards()
+	for i := range after {
+		if (i < 10 || i >= 30) && after[i] != before[i] {
+			t.Errorf("After ShuffleRange(10, 30), card[%d] = %v, want %v", i, after[i], before[i])
+		}
+	}
+	if slices.Equal(after[10:30], before[10:30]) {
+		t.Error("ShuffleRange(10, 30) did not change the order of the range")
+	}
+	if !(&Deck{cards: after[10:30]}).SameMultiset(&Deck{cards: before[10:30]}) {
+		t.Error("ShuffleRange(10, 30) changed the cards in the range")
+	}
+}
+
+func TestShuffleRangeValidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		start, end int
+		wantErr    string
+	}{
+		{"empty range", 5, 5, ""},
+		{"whole deck", 0, 52, ""},
+		{"negative start", -1, 10, "invalid range [-1, 10) for deck of 52 cards"},
+		{"end past deck", 40, 53, "invalid range [40, 53) for deck of 52 cards"},
+		{"start after end", 20, 10, "invalid range [20, 10) for deck of 52 cards"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := New()
+			err := d.ShuffleRange(tt.start, tt.end, NewSeededShuffler(1))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ShuffleRange(%d, %d) got error: %v, want nil", tt.start, tt.end, err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("ShuffleRange(%d, %d) got nil error, want %q", tt.start, tt.end, tt.wantErr)
+			}
+			if got, want := err.Error(), tt.wantErr; got != want {
+				t.Errorf("ShuffleRange(%d, %d) error = %q, want %q", tt.start, tt.end, got, want)
+			}
+			if !errors.Is(err, ErrInvalidArgument) {
+				t.Errorf("ShuffleRange(%d, %d) error = %v, want ErrInvalidArgument", tt.start, tt.end, err)
+			}
+			if !slices.Equal(d.Cards(), New().Cards()) {
+				t.Errorf("ShuffleRange(%d, %d) modified the deck on error", tt.start, tt.end)
+			}
+		})
+	}
+}
+
+func TestAppendTo(t *testing.T) {
+	d := New()
+	_, _ = d.DrawN(50)
+
+	prefix := []Card{NewRedJoker()}
+	got := d.AppendTo(prefix)
+	want := []Card{NewRedJoker(), NewCard(Queen, Clubs), NewCard(King, Clubs)}
+	if !slices.Equal(got, want) {
+		t.Errorf("AppendTo() = %v, want %v", got, want)
+	}
+
+	// The result does not alias the deck
+	got[1] = NewBlackJoker()
+	if card, _ := d.Peek(); card != NewCard(Queen, Clubs) {
+		t.Errorf("After modifying AppendTo() result, Peek() = %v, want %v", card, NewCard(Queen, Clubs))
+	}
+
+	if got := (&Deck{}).AppendTo(nil); len(got) != 0 {
+		t.Errorf("AppendTo(nil) on empty deck = %v, want empty", got)
+	}
+}
+
+func TestAppendToReusesBuffer(t *testing.T) {
+	d := New()
+	buf := make([]Card, 0, 52)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = d.AppendTo(buf[:0])
+	})
+	if allocs != 0 {
+		t.Errorf("AppendTo() with sufficient capacity allocated %v times, want 0", allocs)
+	}
+}
+
+func TestNewFromCards(t *testing.T) {
+	cards := []Card{NewCard(Ace, Spades), NewCard(Ace, Spades), NewRedJoker()}
+	d := NewFromCards(cards)
+
+	if !slices.Equal(d.Cards(), cards) {
+		t.Errorf("NewFromCards(%v).Cards() = %v, want %v", cards, d.Cards(), cards)
+	}
+
+	// The input is copied
+	cards[0] = NewCard(Two, Clubs)
+	if card, _ := d.Peek(); card != NewCard(Ace, Spades) {
+		t.Errorf("After modifying input, Peek() = %v, want %v", card, NewCard(Ace, Spades))
+	}
+
+	// Round-trips with Cards
+	original := New()
+	original.ShuffleWithSeed(9)
+	if got, want := NewFromCards(original.Cards()).Fingerprint(), original.Fingerprint(); got != want {
+		t.Errorf("NewFromCards(d.Cards()).Fingerprint() = %#x, want %#x", got, want)
+	}
+
+	if got := NewFromCards(nil); !got.IsEmpty() {
+		t.Errorf("NewFromCards(nil).Len() = %d, want 0", got.Len())
+	}
+}
+
+func TestDealAll(t *testing.T) {
+	tests := []struct {
+		name       string
+		numPlayers int
+		wantSizes  []int
+	}{
+		{"one player", 1, []int{52}},
+		{"two players", 2, []int{26, 26}},
+		{"three players", 3, []int{18, 17, 17}},
+		{"five players", 5, []int{11, 11, 10, 10, 10}},
+		{"more players than cards", 60, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := New()
+			hands, err := d.DealAll(tt.numPlayers)
+			if err != nil {
+				t.Fatalf("DealAll(%d) got error: %v, want nil", tt.numPlayers, err)
+			}
+			if got, want := len(hands), tt.numPlayers; got != want {
+				t.Fatalf("DealAll(%d) returned %d hands, want %d", tt.numPlayers, got, want)
+			}
+			for i, size := range tt.wantSizes {
+				if got := len(hands[i]); got != size {
+					t.Errorf("DealAll(%d) hand %d has %d cards, want %d", tt.numPlayers, i, got, size)
+				}
+			}
+
+			// Cards are dealt round-robin
+			all := New().Cards()
+			for i, card := range all {
+				if got := hands[i%tt.numPlayers][i/tt.numPlayers]; got != card {
+					t.Errorf("DealAll(%d)[%d][%d] = %v, want %v", tt.numPlayers, i%tt.numPlayers, i/tt.numPlayers, got, card)
+				}
+			}
+
+			if !d.IsEmpty() {
+				t.Errorf("After DealAll(%d), deck.Len() = %d, want 0", tt.numPlayers, d.Len())
+			}
+		})
+	}
+}
+
+func TestDealAllValidation(t *testing.T) {
+	d := New()
+	hands, err := d.DealAll(0)
+	if err == nil {
+		t.Fatal("DealAll(0) got nil error, want error")
+	}
+	if got, want := err.Error(), "number of players must be at least 1"; got != want {
+		t.Errorf("DealAll(0) error = %q, want %q", got, want)
+	}
+	if hands != nil {
+		t.Errorf("DealAll(0) = %v, want nil", hands)
+	}
+	if got, want := d.Len(), 52; got != want {
+		t.Errorf("After failed DealAll, deck.Len() = %d, want %d", got, want)
+	}
+}
+
+func TestCardAdjacency(t *testing.T) {
+	none := Card(0)
+	tests := []struct {
+		name       string
+		card       Card
+		next, prev Card
+		nextHigh   Card
+		prevHigh   Card
+	}{
+		{"ace", NewCard(Ace, Spades), NewCard(Two, Spades), none, none, NewCard(King, Spades)},
+		{"two", NewCard(Two, Hearts), NewCard(Three, Hearts), NewCard(Ace, Hearts), NewCard(Three, Hearts), none},
+		{"seven", NewCard(Seven, Diamonds), NewCard(Eight, Diamonds), NewCard(Six, Diamonds), NewCard(Eight, Diamonds), NewCard(Six, Diamonds)},
+		{"king", NewCard(King, Clubs), none, NewCard(Queen, Clubs), NewCard(Ace, Clubs), NewCard(Queen, Clubs)},
+		{"red joker", NewRedJoker(), none, none, none, none},
+		{"black joker", NewBlackJoker(), none, none, none, none},
+		{"invalid", Card(0), none, none, none, none},
+	}
+
+	check := func(t *testing.T, method string, got Card, ok bool, want Card) {
+		t.Helper()
+		if wantOK := want != none; got != want || ok != wantOK {
+			t.Errorf("%s() = (%v, %v), want (%v, %v)", method, got, ok, want, wantOK)
+		}
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.card.NextInSuit()
+			check(t, "NextInSuit", got, ok, tt.next)
+			got, ok = tt.card.PrevInSuit()
+			check(t, "PrevInSuit", got, ok, tt.prev)
+			got, ok = tt.card.NextInSuitAceHigh()
+			check(t, "NextInSuitAceHigh", got, ok, tt.nextHigh)
+			got, ok = tt.card.PrevInSuitAceHigh()
+			check(t, "PrevInSuitAceHigh", got, ok, tt.prevHigh)
+		})
+	}
+}
+
+func TestIsSorted(t *testing.T) {
+	shuffled := NewWithJokers()
+	shuffled.ShuffleWithSeed(4)
+
+	jokersFirst := NewWithJokers()
+	jokersFirst.CycleN(-1)
+
+	tests := []struct {
+		name string
+		deck *Deck
+		want bool
+	}{
+		{"empty", &Deck{}, true},
+		{"single card", NewFromCards([]Card{NewCard(King, Clubs)}), true},
+		{"new deck", New(), true},
+		{"new deck with jokers", NewWithJokers(), true},
+		{"duplicates in order", NewFromCards([]Card{NewCard(Ace, Spades), NewCard(Ace, Spades)}), true},
+		{"shuffled", shuffled, false},
+		{"joker before regular card", jokersFirst, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.deck.IsSorted(); got != tt.want {
+				t.Errorf("IsSorted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	shuffled.Sort()
+	if !shuffled.IsSorted() {
+		t.Error("IsSorted() = false after Sort(), want true")
+	}
+}
+
+func TestIsSortedDoesNotAllocate(t *testing.T) {
+	d := New()
+	allocs := testing.AllocsPerRun(100, func() {
+		d.IsSorted()
+	})
+	if allocs != 0 {
+		t.Errorf("IsSorted() allocated %v times, want 0", allocs)
+	}
+}
+
+func TestSortBySuitOrder(t *testing.T) {
+	d := NewWithJokers()
+	d.ShuffleWithSeed(8)
+
+	order := SuitOrder{Clubs, Diamonds, Hearts, Spades}
+	if err := d.SortBySuitOrder(order); err != nil {
+		t.Fatalf("SortBySuitOrder(%v) got error: %v, want nil", order, err)
+	}
+
+	cards := d.Cards()
+	for i, s := range order {
+		for r := Ace; r <= King; r++ {
+			idx := i*13 + int(r-Ace)
+			if got, want := cards[idx], NewCard(r, s); got != want {
+				t.Errorf("After SortBySuitOrder(%v), card[%d] = %v, want %v", order, idx, got, want)
+			}
+		}
+	}
+	if got, want := cards[52], NewRedJoker(); got != want {
+		t.Errorf("After SortBySuitOrder(%v), card[52] = %v, want %v", order, got, want)
+	}
+	if got, want := cards[53], NewBlackJoker(); got != want {
+		t.Errorf("After SortBySuitOrder(%v), card[53] = %v, want %v", order, got, want)
+	}
+
+	// The default order matches Sort
+	if err := d.SortBySuitOrder(SuitOrder{Spades, Hearts, Diamonds, Clubs}); err != nil {
+		t.Fatalf("SortBySuitOrder() with default order got error: %v", err)
+	}
+	if !d.IsSorted() {
+		t.Error("SortBySuitOrder() with default order did not match Sort()")
+	}
+}
+
+func TestSortBySuitOrderValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		order   SuitOrder
+		wantErr string
+	}{
+		{"duplicate suit", SuitOrder{Spades, Spades, Diamonds, Clubs}, "invalid suit order: Spades at index 1 is repeated"},
+		{"out of range suit", SuitOrder{Spades, Hearts, Diamonds, Suit(4)}, "invalid suit order: suit 4 at index 3 is out of range"},
+		{"far out of range suit", SuitOrder{Spades, Hearts, Diamonds, Suit(7)}, "invalid suit order: suit 7 at index 3 is out of range"},
+		{"zero value", SuitOrder{}, "invalid suit order: Spades at index 1 is repeated"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := New()
+			d.ShuffleWithSeed(2)
+			before := d.Cards()
+
+			err := d.SortBySuitOrder(tt.order)
+			if err == nil {
+				t.Fatalf("SortBySuitOrder(%d) got nil error, want error", tt.order)
+			}
+			if got := err.Error(); got != tt.wantErr {
+				t.Errorf("SortBySuitOrder(%d) error = %q, want %q", tt.order, got, tt.wantErr)
+			}
+			if !slices.Equal(d.Cards(), before) {
+				t.Errorf("SortBySuitOrder(%d) modified the deck on error", tt.order)
+			}
+		})
+	}
+}
+
+func TestReturnedSlicesDoNotAliasDeck(t *testing.T) {
+	tests := []struct {
+		name string
+		get  func(d *Deck) []Card
+	}{
+		{"Cards", func(d *Deck) []Card { return d.Cards() }},
+		{"PeekN", func(d *Deck) []Card { cards, _ := d.PeekN(5); return cards }},
+		{"DrawN", func(d *Deck) []Card { cards, _ := d.DrawN(5); return cards }},
+		{"Deal", func(d *Deck) []Card { hands, _ := d.Deal(2, 5); return hands[0] }},
+		{"DealHands", func(d *Deck) []Card { hands, _ := d.DealHands([]int{5, 5}); return hands[0] }},
+		{"DealNoJokers", func(d *Deck) []Card { hands, _ := d.DealNoJokers(2, 5); return hands[0] }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := New()
+			cards := tt.get(d)
+			before := d.Cards()
+
+			if got, want := cap(cards), len(cards); got != want {
+				t.Errorf("%s() returned slice with cap %d, want %d", tt.name, got, want)
+			}
+
+			// Appending must not write into the deck's backing array
+			cards = append(cards, NewRedJoker(), NewBlackJoker())
+			for i := range cards {
+				cards[i] = NewRedJoker()
+			}
+			if !slices.Equal(d.Cards(), before) {
+				t.Errorf("Appending to %s() result modified the deck", tt.name)
+			}
+		})
+	}
+}
+
+func TestErrorKinds(t *testing.T) {
+	tests := []struct {
+		name    string
+		call    func(d *Deck) error
+		wantErr error
+		wantMsg string
+	}{
+		{"Deal zero players", func(d *Deck) error { _, err := d.Deal(0, 5); return err }, ErrInvalidArgument, "number of players must be at least 1"},
+		{"Deal zero cards", func(d *Deck) error { _, err := d.Deal(4, 0); return err }, ErrInvalidArgument, "cards per player must be at least 1"},
+		{"Deal too many cards", func(d *Deck) error { _, err := d.Deal(4, 14); return err }, ErrInsufficientCards, "insufficient cards: need 56, have 52"},
+		{"DealHands empty", func(d *Deck) error { _, err := d.DealHands(nil); return err }, ErrInvalidArgument, "handSizes must contain at least one hand"},
+		{"DealHands negative", func(d *Deck) error { _, err := d.DealHands([]int{5, -1}); return err }, ErrInvalidArgument, "hand size must be positive: got -1 at index 1"},
+		{"DealHands too many cards", func(d *Deck) error { _, err := d.DealHands([]int{30, 30}); return err }, ErrInsufficientCards, "insufficient cards: need 60, have 52"},
+		{"DrawN negative", func(d *Deck) error { _, err := d.DrawN(-1); return err }, ErrInvalidArgument, "cannot draw negative number of cards: -1"},
+		{"DrawN too many", func(d *Deck) error { _, err := d.DrawN(53); return err }, ErrInsufficientCards, "not enough cards in deck: have 52, need 53"},
+		{"PeekN too many", func(d *Deck) error { _, err := d.PeekN(53); return err }, ErrInsufficientCards, "not enough cards in deck: have 52, need 53"},
+		{"Draw empty", func(d *Deck) error { _, _ = d.DrawN(52); _, err := d.Draw(); return err }, ErrInsufficientCards, "cannot draw from empty deck"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call(New())
+			if err == nil {
+				t.Fatalf("got nil error, want %q", tt.wantMsg)
+			}
+			if got, want := err.Error(), tt.wantMsg; got != want {
+				t.Errorf("error = %q, want %q", got, want)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("errors.Is(%v, %v) = false, want true", err, tt.wantErr)
+			}
+			for _, other := range []error{ErrInvalidArgument, ErrInsufficientCards} {
+				if other != tt.wantErr && errors.Is(err, other) {
+					t.Errorf("errors.Is(%v, %v) = true, want false", err, other)
+				}
+			}
+		})
+	}
+}
+
+func TestCutReveal(t *testing.T) {
+	d := New()
+	before := d.Cards()
+
+	counts := make(map[Card]int)
+	shuffler := NewSeededShuffler(12)
+	const trials = 52 * 200
+	for range trials {
+		starter, err := d.CutReveal(shuffler)
+		if err != nil {
+			t.Fatalf("CutReveal() got error: %v, want nil", err)
+		}
+		counts[starter]++
+	}
+
+	if !slices.Equal(d.Cards(), before) {
+		t.Error("CutReveal() modified the deck")
+	}
+
+	// Every card should be revealed with roughly equal frequency
+	if got, want := len(counts), 52; got != want {
+		t.Fatalf("CutReveal() revealed %d distinct cards, want %d", got, want)
+	}
+	for card, n := range counts {
+		if n < 100 || n > 320 {
+			t.Errorf("CutReveal() revealed %v %d times in %d trials, want about %d", card, n, trials, trials/52)
+		}
+	}
+
+	// Identical seeds reveal identical cards
+	a, _ := d.CutReveal(NewSeededShuffler(3))
+	b, _ := d.CutReveal(NewSeededShuffler(3))
+	if a != b {
+		t.Errorf("CutReveal() with the same seed = %v and %v, want equal", a, b)
+	}
+}
+
+func TestCutRevealEmpty(t *testing.T) {
+	d := &Deck{}
+	card, err := d.CutReveal(SecureShuffler{})
+	if err == nil {
+		t.Fatal("CutReveal() on empty deck got nil error")
+	}
+	if got, want := err.Error(), "cannot cut empty deck"; got != want {
+		t.Errorf("CutReveal() error = %q, want %q", got, want)
+	}
+	if !errors.Is(err, ErrInsufficientCards) {
+		t.Errorf("errors.Is(%v, ErrInsufficientCards) = false, want true", err)
+	}
+	if card != Card(0) {
+		t.Errorf("CutReveal() on empty deck = %v, want zero Card", card)
+	}
+}
+
+func TestDealHoldem(t *testing.T) {
+	d := New()
+	all := d.Cards()
+
+	holes, flop, turn, river, err := d.DealHoldem(3)
+	if err != nil {
+		t.Fatalf("DealHoldem(3) got error: %v, want nil", err)
+	}
+
+	// Hole cards are dealt one at a time: 0 1 2 0 1 2
+	wantHoles := [][]Card{{all[0], all[3]}, {all[1], all[4]}, {all[2], all[5]}}
+	for i := range wantHoles {
+		if !slices.Equal(holes[i], wantHoles[i]) {
+			t.Errorf("DealHoldem(3) holes[%d] = %v, want %v", i, holes[i], wantHoles[i])
+		}
+	}
+
+	// Burn, flop, burn, turn, burn, river
+	if want := all[7:10]; !slices.Equal(flop, want) {
+		t.Errorf("DealHoldem(3) flop = %v, want %v", flop, want)
+	}
+	if want := all[11:12]; !slices.Equal(turn, want) {
+		t.Errorf("DealHoldem(3) turn = %v, want %v", turn, want)
+	}
+	if want := all[13:14]; !slices.Equal(river, want) {
+		t.Errorf("DealHoldem(3) river = %v, want %v", river, want)
+	}
+
+	if got, want := d.Len(), 52-14; got != want {
+		t.Errorf("After DealHoldem(3), deck.Len() = %d, want %d", got, want)
+	}
+}
+
+func TestDealHoldemValidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		numPlayers int
+		wantErr    string
+	}{
+		{"zero players", 0, "number of players must be at least 1"},
+		{"too many players", 23, "insufficient cards: need 54, have 52"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := New()
+			holes, flop, turn, river, err := d.DealHoldem(tt.numPlayers)
+			if err == nil {
+				t.Fatalf("DealHoldem(%d) got nil error, want %q", tt.numPlayers, tt.wantErr)
+			}
+			if got, want := err.Error(), tt.wantErr; got != want {
+				t.Errorf("DealHoldem(%d) error = %q, want %q", tt.numPlayers, got, want)
+			}
+			if holes != nil || flop != nil || turn != nil || river != nil {
+				t.Errorf("DealHoldem(%d) returned cards along with an error", tt.numPlayers)
+			}
+			if got, want := d.Len(), 52; got != want {
+				t.Errorf("After failed DealHoldem(%d), deck.Len() = %d, want %d", tt.numPlayers, got, want)
+			}
+		})
+	}
+
+	// 22 players use exactly the whole deck
+	d := New()
+	if _, _, _, _, err := d.DealHoldem(22); err != nil {
+		t.Errorf("DealHoldem(22) got error: %v, want nil", err)
+	}
+	if !d.IsEmpty() {
+		t.Errorf("After DealHoldem(22), deck.Len() = %d, want 0", d.Len())
+	}
+}
+
+func TestTakeRandom(t *testing.T) {
+	d := New()
+	shuffler := NewSeededShuffler(21)
+
+	taken := make(map[Card]bool)
+	for d.Len() > 0 {
+		before := d.Cards()
+		card, err := d.TakeRandom(shuffler)
+		if err != nil {
+			t.Fatalf("TakeRandom() got error: %v, want nil", err)
+		}
+		if taken[card] {
+			t.Fatalf("TakeRandom() returned %v twice", card)
+		}
+		taken[card] = true
+
+		// The remaining cards keep their order
+		i := slices.Index(before, card)
+		want := slices.Delete(before, i, i+1)
+		if !slices.Equal(d.Cards(), want) {
+			t.Fatalf("After TakeRandom() = %v, deck = %v, want %v", card, d, want)
+		}
+	}
+	if got, want := len(taken), 52; got != want {
+		t.Errorf("TakeRandom() returned %d distinct cards, want %d", got, want)
+	}
+
+	_, err := d.TakeRandom(shuffler)
+	if err == nil {
+		t.Fatal("TakeRandom() on empty deck got nil error")
+	}
+	if got, want := err.Error(), "cannot take from empty deck"; got != want {
+		t.Errorf("TakeRandom() error = %q, want %q", got, want)
+	}
+}
+
+func TestTakeRandomDistribution(t *testing.T) {
+	shuffler := NewSeededShuffler(6)
+	counts := make(map[Card]int)
+	const trials = 52 * 200
+	for range trials {
+		card, _ := New().TakeRandom(shuffler)
+		counts[card]++
+	}
+	for card, n := range counts {
+		if n < 100 || n > 320 {
+			t.Errorf("TakeRandom() returned %v %d times in %d trials, want about %d", card, n, trials, trials/52)
+		}
+	}
+}
+
+func TestPeekIndices(t *testing.T) {
+	d := New()
+	all := d.Cards()
+
+	tests := []struct {
+		name    string
+		indices []int
+		want    []Card
+		wantErr string
+	}{
+		{"none", nil, []Card{}, ""},
+		{"top and bottom", []int{0, 51}, []Card{all[0], all[51]}, ""},
+		{"unordered with repeats", []int{30, 5, 30}, []Card{all[30], all[5], all[30]}, ""},
+		{"negative", []int{3, -1}, nil, "index out of range: -1 (deck has 52 cards)"},
+		{"past end", []int{52}, nil, "index out of range: 52 (deck has 52 cards)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := d.PeekIndices(tt.indices)
+			if tt.wantErr != "" {
+				if err == nil {
+					t.Fatalf("PeekIndices(%v) got nil error, want %q", tt.indices, tt.wantErr)
+				}
+				if got, want := err.Error(), tt.wantErr; got != want {
+					t.Errorf("PeekIndices(%v) error = %q, want %q", tt.indices, got, want)
+				}
+				if !errors.Is(err, ErrInvalidArgument) {
+					t.Errorf("PeekIndices(%v) error = %v, want ErrInvalidArgument", tt.indices, err)
+				}
+				if got != nil {
+					t.Errorf("PeekIndices(%v) = %v, want nil when error occurs", tt.indices, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("PeekIndices(%v) got error: %v, want nil", tt.indices, err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("PeekIndices(%v) = %v, want %v", tt.indices, got, tt.want)
+			}
+		})
+	}
+
+	if !slices.Equal(d.Cards(), all) {
+		t.Error("PeekIndices() modified the deck")
+	}
+}
+
+// assertUnchangedAfterPanic calls fn, which must panic, and checks that the
+// deck has not been modified.
+func assertUnchangedAfterPanic(t *testing.T, d *Deck, fn func()) {
+	t.Helper()
+	before := d.Cards()
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("expected panic, got none")
+			}
+		}()
+		fn()
+	}()
+
+	if !slices.Equal(d.Cards(), before) {
+		t.Errorf("deck = %v after panic, want %v (deck should be unchanged)", d, before)
+	}
+}
+
+// panickingShuffler performs a few swaps and then panics.
+type panickingShuffler struct{}
+
+func (panickingShuffler) Shuffle(n int, swap func(i, j int)) {
+	swap(0, n-1)
+	swap(1, n-2)
+	panic("shuffler failed")
+}
+
+func TestCallbackPanicLeavesDeckUnchanged(t *testing.T) {
+	panicAfter := func(n int) func(Card) bool {
+		calls := 0
+		return func(c Card) bool {
+			calls++
+			if calls > n {
+				panic("predicate failed")
+			}
+			return c.Rank() == Ace
+		}
+	}
+
+	tests := []struct {
+		name string
+		call func(d *Deck)
+	}{
+		{"TakeRandom", func(d *Deck) { _, _ = d.TakeRandom(panickingShuffler{}) }},
+		{"CutReveal", func(d *Deck) { _, _ = d.CutReveal(panickingShuffler{}) }},
+		{"DrawWhile", func(d *Deck) { _, _ = d.DrawWhile(func(Card) bool { panic("predicate failed") }) }},
+		{"DrawUntil", func(d *Deck) { _, _, _ = d.DrawUntil(func(Card) bool { panic("predicate failed") }) }},
+		{"DealMatching", func(d *Deck) { _, _, _ = d.DealMatching(2, 5, panicAfter(30)) }},
+		{"DrawOrReshuffle", func(d *Deck) { _, _ = NewFromCards(nil).DrawOrReshuffle(d, panickingShuffler{}) }},
+		{"DealFunc", func(d *Deck) {
+			_, _ = d.DealFunc(4, 5, func(player int, _ Card) {
+				if player == 3 {
+					panic("callback failed")
+				}
+			})
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := New()
+			d.ShuffleWithSeed(13)
+			assertUnchangedAfterPanic(t, d, func() { tt.call(d) })
+		})
+	}
+}
+
+func TestAllCards(t *testing.T) {
+	cards := AllCards()
+	if got, want := len(cards), 52; got != want {
+		t.Fatalf("len(AllCards()) = %d, want %d", got, want)
+	}
+	for i, card := range cards {
+		if got, want := card.Ordinal(), i; got != want {
+			t.Errorf("AllCards()[%d] = %v with ordinal %d, want ordinal %d", i, card, got, want)
+		}
+	}
+	if !slices.Equal(cards, New().Cards()) {
+		t.Error("AllCards() does not match New().Cards()")
+	}
+
+	withJokers := AllCardsWithJokers()
+	if got, want := len(withJokers), 54; got != want {
+		t.Fatalf("len(AllCardsWithJokers()) = %d, want %d", got, want)
+	}
+	if !slices.Equal(withJokers[:52], cards) {
+		t.Error("AllCardsWithJokers()[:52] does not match AllCards()")
+	}
+	if withJokers[52] != NewRedJoker() || withJokers[53] != NewBlackJoker() {
+		t.Errorf("AllCardsWithJokers()[52:] = %v, want [%v %v]", withJokers[52:], NewRedJoker(), NewBlackJoker())
+	}
+
+	// Each call returns a fresh slice
+	cards[0] = NewRedJoker()
+	if got, want := AllCards()[0], NewCard(Ace, Spades); got != want {
+		t.Errorf("After modifying a previous result, AllCards()[0] = %v, want %v", got, want)
+	}
+}
+
+func TestShuffleWithBytes(t *testing.T) {
+	seed := []byte("client-nonce:7f3a|server-nonce:91c2e0d4b8a6f5e3c1d7b9a2e4f6c8d0")
+
+	d1 := New()
+	d1.ShuffleWithBytes(seed)
+	d2 := New()
+	d2.ShuffleWithBytes(bytes.Clone(seed))
+	if got, want := d2.Fingerprint(), d1.Fingerprint(); got != want {
+		t.Errorf("ShuffleWithBytes() with the same seed gave fingerprints %#x and %#x, want equal", got, want)
+	}
+	if d1.IsSorted() {
+		t.Error("ShuffleWithBytes() did not change the order of the deck")
+	}
+	if !d1.SameMultiset(New()) {
+		t.Error("ShuffleWithBytes() lost or duplicated cards")
+	}
+
+	// Any difference in the seed, including its length, changes the shuffle
+	for _, other := range [][]byte{seed[:len(seed)-1], append(bytes.Clone(seed), 0), nil} {
+		d3 := New()
+		d3.ShuffleWithBytes(other)
+		if d3.Fingerprint() == d1.Fingerprint() {
+			t.Errorf("ShuffleWithBytes(%q) produced the same order as ShuffleWithBytes(%q)", other, seed)
+		}
+	}
+
+	// The shuffle can be verified like ShuffleCommit
+	if !VerifyShuffle(New().Cards(), seed, d1) {
+		t.Error("VerifyShuffle() = false for a ShuffleWithBytes() result, want true")
+	}
+}
+
+func TestShuffleWithBytesGolden(t *testing.T) {
+	// These values are part of the ShuffleWithBytes contract and must never
+	// change, or seeds revealed after play would no longer verify
+	d := New()
+	d.ShuffleWithBytes([]byte("client-nonce:7f3a|server-nonce:91c2e0d4b8a6f5e3c1d7b9a2e4f6c8d0"))
+	want := []Card{
+		NewCard(Four, Diamonds),
+		NewCard(Eight, Hearts),
+		NewCard(Eight, Diamonds),
+		NewCard(Three, Hearts),
+		NewCard(Three, Diamonds),
+		NewCard(Three, Spades),
+		NewCard(Ten, Hearts),
+		NewCard(Two, Diamonds),
+	}
+	if got := d.Cards()[:len(want)]; !slices.Equal(got, want) {
+		t.Errorf("After ShuffleWithBytes(), top cards = %v, want %v", got, want)
+	}
+}
+
+func TestShuffleWithString(t *testing.T) {
+	// These values are part of the ShuffleWithString contract and must never change
+	if got, want := stringShuffleSeed("daily-2026-10-15"), uint64(0xc354da3b68a9f1c2); got != want {
+		t.Errorf("stringShuffleSeed(%q) = %#x, want %#x", "daily-2026-10-15", got, want)
+	}
+
+	d := New()
+	d.ShuffleWithString("daily-2026-10-15")
+	want := []Card{
+		NewCard(Four, Diamonds),
+		NewCard(Ace, Hearts),
+		NewCard(Nine, Spades),
+		NewCard(Jack, Clubs),
+		NewCard(Eight, Diamonds),
+		NewCard(Five, Hearts),
+		NewCard(Six, Spades),
+		NewCard(Ten, Spades),
+	}
+	if got := d.Cards()[:len(want)]; !slices.Equal(got, want) {
+		t.Errorf("After ShuffleWithString(%q), top cards = %v, want %v", "daily-2026-10-15", got, want)
+	}
+	if !d.SameMultiset(New()) {
+		t.Error("ShuffleWithString() lost or duplicated cards")
+	}
+
+	// Distinct seeds, including ones differing only in case or by a prefix, give distinct orders
+	seen := map[uint64]string{d.Fingerprint(): "daily-2026-10-15"}
+	for _, seed := range []string{"", "daily-2026-10-16", "Daily-2026-10-15", "daily-2026-10-1", "daily-2026-10-15 "} {
+		other := New()
+		other.ShuffleWithString(seed)
+		if prev, ok := seen[other.Fingerprint()]; ok {
+			t.Errorf("ShuffleWithString(%q) produced the same order as ShuffleWithString(%q)", seed, prev)
+		}
+		seen[other.Fingerprint()] = seed
+	}
+}
+
+func TestDealTracked(t *testing.T) {
+	d := New()
+	perm := d.ShuffleTracked(NewSeededShuffler(31))
+	before := d.Cards()
+
+	hands, positions, err := d.DealTracked(3, 4)
+	if err != nil {
+		t.Fatalf("DealTracked(3, 4) got error: %v, want nil", err)
+	}
+
+	original := New().Cards()
+	for i := range hands {
+		if got, want := len(positions[i]), len(hands[i]); got != want {
+			t.Fatalf("DealTracked(3, 4) positions[%d] has %d entries, want %d", i, got, want)
+		}
+		for k, pos := range positions[i] {
+			if got, want := hands[i][k], before[pos]; got != want {
+				t.Errorf("DealTracked(3, 4) hands[%d][%d] = %v, but position %d held %v", i, k, got, pos, want)
+			}
+			// Reconstruct from the unshuffled deck using the logged permutation
+			if got, want := original[perm[pos]], hands[i][k]; got != want {
+				t.Errorf("Replayed card for hands[%d][%d] = %v, want %v", i, k, got, want)
+			}
+		}
+	}
+
+	if got, want := d.Len(), 40; got != want {
+		t.Errorf("After DealTracked(3, 4), deck.Len() = %d, want %d", got, want)
+	}
+
+	_, _, err = d.DealTracked(4, 11)
+	if err == nil {
+		t.Fatal("DealTracked(4, 11) got nil error, want error")
+	}
+	if got, want := err.Error(), "insufficient cards: need 44, have 40"; got != want {
+		t.Errorf("DealTracked(4, 11) error = %q, want %q", got, want)
+	}
+	if got, want := d.Len(), 40; got != want {
+		t.Errorf("After failed DealTracked, deck.Len() = %d, want %d", got, want)
+	}
+}
+
+func TestConcat(t *testing.T) {
+	a := NewFromCards([]Card{NewCard(Ace, Spades), NewCard(Two, Spades)})
+	b := &Deck{}
+	c := NewFromCards([]Card{NewRedJoker()})
+
+	got := Concat(a, b, nil, c)
+	want := []Card{NewCard(Ace, Spades), NewCard(Two, Spades), NewRedJoker()}
+	if !slices.Equal(got.Cards(), want) {
+		t.Errorf("Concat() = %v, want %v", got, want)
+	}
+
+	// Inputs are unchanged and independent of the result
+	_, _ = got.Draw()
+	got.Add(NewBlackJoker())
+	if got, want := a.Len(), 2; got != want {
+		t.Errorf("After Concat(), a.Len() = %d, want %d", got, want)
+	}
+	if got, want := b.Len(), 0; got != want {
+		t.Errorf("After Concat(), b.Len() = %d, want %d", got, want)
+	}
+	if got, want := c.Len(), 1; got != want {
+		t.Errorf("After Concat(), c.Len() = %d, want %d", got, want)
+	}
+	if card, _ := a.Peek(); card != NewCard(Ace, Spades) {
+		t.Errorf("After Concat(), a.Peek() = %v, want %v", card, NewCard(Ace, Spades))
+	}
+
+	if got := Concat(); !got.IsEmpty() {
+		t.Errorf("Concat().Len() = %d, want 0", got.Len())
+	}
+
+	// A deck may appear more than once
+	if got, want := Concat(a, a).Len(), 4; got != want {
+		t.Errorf("Concat(a, a).Len() = %d, want %d", got, want)
+	}
+}
+
+func TestSortAlternatingColors(t *testing.T) {
+	isRed := func(c Card) bool { return c.Suit() == Hearts || c.Suit() == Diamonds }
+
+	t.Run("full deck", func(t *testing.T) {
+		d := NewWithJokers()
+		d.ShuffleWithSeed(10)
+		d.SortAlternatingColors()
+
+		cards := d.Cards()
+		for i := 0; i < 52; i++ {
+			if got, want := isRed(cards[i]), i%2 == 1; got != want {
+				t.Errorf("card[%d] = %v, red = %v, want %v", i, cards[i], got, want)
+			}
+		}
+		want := []Card{NewCard(Ace, Spades), NewCard(Ace, Hearts), NewCard(Two, Spades), NewCard(Two, Hearts)}
+		if !slices.Equal(cards[:4], want) {
+			t.Errorf("first cards = %v, want %v", cards[:4], want)
+		}
+		// Clubs follow Spades and Diamonds follow Hearts
+		if got, want := cards[26], NewCard(Ace, Clubs); got != want {
+			t.Errorf("card[26] = %v, want %v", got, want)
+		}
+		if got, want := cards[27], NewCard(Ace, Diamonds); got != want {
+			t.Errorf("card[27] = %v, want %v", got, want)
+		}
+		if got, want := cards[52:], []Card{NewRedJoker(), NewBlackJoker()}; !slices.Equal(got, want) {
+			t.Errorf("last cards = %v, want %v", got, want)
+		}
+	})
+
+	tests := []struct {
+		name string
+		in   []Card
+		want []Card
+	}{
+		{
+			"more red than black",
+			[]Card{NewCard(King, Hearts), NewCard(Two, Clubs), NewCard(Five, Diamonds), NewCard(Three, Hearts)},
+			[]Card{NewCard(Three, Hearts), NewCard(Two, Clubs), NewCard(King, Hearts), NewCard(Five, Diamonds)},
+		},
+		{
+			"equal counts start with black",
+			[]Card{NewCard(Queen, Diamonds), NewCard(Jack, Spades)},
+			[]Card{NewCard(Jack, Spades), NewCard(Queen, Diamonds)},
+		},
+		{
+			"single color",
+			[]Card{NewBlackJoker(), NewCard(Four, Clubs), NewCard(Ace, Spades)},
+			[]Card{NewCard(Ace, Spades), NewCard(Four, Clubs), NewBlackJoker()},
+		},
+		{"empty", nil, []Card{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewFromCards(tt.in)
+			d.SortAlternatingColors()
+			if got := d.Cards(); !slices.Equal(got, tt.want) {
+				t.Errorf("SortAlternatingColors() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDealWithVisibility(t *testing.T) {
+	d := New()
+	all := d.Cards()
+	pattern := []bool{false, false, true, true, true, true, false}
+
+	hands, faceUp, err := d.DealWithVisibility(3, pattern)
+	if err != nil {
+		t.Fatalf("DealWithVisibility(3, pattern) got error: %v, want nil", err)
+	}
+
+	for i := range hands {
+		if got, want := len(hands[i]), len(pattern); got != want {
+			t.Fatalf("hands[%d] has %d cards, want %d", i, got, want)
+		}
+		if !slices.Equal(faceUp[i], pattern) {
+			t.Errorf("faceUp[%d] = %v, want %v", i, faceUp[i], pattern)
+		}
+		// Cards are dealt round-robin
+		for k, card := range hands[i] {
+			if want := all[k*3+i]; card != want {
+				t.Errorf("hands[%d][%d] = %v, want %v", i, k, card, want)
+			}
+		}
+	}
+
+	// Visibility slices are independent
+	faceUp[0][0] = true
+	if faceUp[1][0] || pattern[0] {
+		t.Error("Modifying faceUp[0] affected another player or the pattern")
+	}
+
+	if got, want := d.Len(), 52-21; got != want {
+		t.Errorf("After DealWithVisibility(3, pattern), deck.Len() = %d, want %d", got, want)
+	}
+}
+
+func TestDealWithVisibilityValidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		numPlayers int
+		pattern    []bool
+		wantErr    string
+	}{
+		{"zero players", 0, []bool{true}, "number of players must be at least 1"},
+		{"empty pattern", 2, nil, "cards per player must be at least 1"},
+		{"insufficient cards", 8, make([]bool, 7), "insufficient cards: need 56, have 52"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := New()
+			hands, faceUp, err := d.DealWithVisibility(tt.numPlayers, tt.pattern)
+			if err == nil {
+				t.Fatalf("DealWithVisibility(%d, %v) got nil error, want %q", tt.numPlayers, tt.pattern, tt.wantErr)
+			}
+			if got, want := err.Error(), tt.wantErr; got != want {
+				t.Errorf("DealWithVisibility(%d, %v) error = %q, want %q", tt.numPlayers, tt.pattern, got, want)
+			}
+			if hands != nil || faceUp != nil {
+				t.Errorf("DealWithVisibility(%d, %v) returned results along with an error", tt.numPlayers, tt.pattern)
+			}
+			if got, want := d.Len(), 52; got != want {
+				t.Errorf("After failed DealWithVisibility, deck.Len() = %d, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestCardAccessibleName(t *testing.T) {
+	tests := []struct {
+		card            Card
+		want            string
+		wantWithArticle string
+	}{
+		{NewCard(Ace, Spades), "Ace of Spades", "the Ace of Spades"},
+		{NewCard(Ten, Diamonds), "10 of Diamonds", "the 10 of Diamonds"},
+		{NewCard(Queen, Hearts), "Queen of Hearts", "the Queen of Hearts"},
+		{NewRedJoker(), "Red Joker", "the Red Joker"},
+		{NewBlackJoker(), "Black Joker", "the Black Joker"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.card.AccessibleName(); got != tt.want {
+				t.Errorf("AccessibleName() = %q, want %q", got, tt.want)
+			}
+			if got := tt.card.AccessibleNameWithArticle(); got != tt.wantWithArticle {
+				t.Errorf("AccessibleNameWithArticle() = %q, want %q", got, tt.wantWithArticle)
+			}
+		})
+	}
+}
+
+func TestDealFunc(t *testing.T) {
+	d := New()
+	all := d.Cards()
+
+	type dealt struct {
+		player int
+		card   Card
+	}
+	var calls []dealt
+	hands, err := d.DealFunc(3, 4, func(player int, c Card) {
+		calls = append(calls, dealt{player, c})
+	})
+	if err != nil {
+		t.Fatalf("DealFunc(3, 4, fn) got error: %v, want nil", err)
+	}
+
+	if got, want := len(calls), 12; got != want {
+		t.Fatalf("DealFunc(3, 4, fn) called onDeal %d times, want %d", got, want)
+	}
+	for i, call := range calls {
+		if want := (dealt{i % 3, all[i]}); call != want {
+			t.Errorf("onDeal call %d = %v, want %v", i, call, want)
+		}
+	}
+
+	for i := range hands {
+		for k, card := range hands[i] {
+			if want := all[k*3+i]; card != want {
+				t.Errorf("hands[%d][%d] = %v, want %v", i, k, card, want)
+			}
+		}
+	}
+
+	if got, want := d.Len(), 52-12; got != want {
+		t.Errorf("After DealFunc(3, 4, fn), deck.Len() = %d, want %d", got, want)
+	}
+
+	// A nil callback deals like DealFrom(0, ...)
+	want, _ := New().DealFrom(0, 2, 3)
+	got, err := New().DealFunc(2, 3, nil)
+	if err != nil {
+		t.Fatalf("DealFunc(2, 3, nil) got error: %v, want nil", err)
+	}
+	for i := range want {
+		if !slices.Equal(got[i], want[i]) {
+			t.Errorf("DealFunc(2, 3, nil)[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestDealFuncValidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		numPlayers int
+		cardsEach  int
+		wantErr    string
+	}{
+		{"zero players", 0, 5, "number of players must be at least 1"},
+		{"zero cards each", 4, 0, "cards per player must be at least 1"},
+		{"insufficient cards", 4, 14, "insufficient cards: need 56, have 52"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := New()
+			called := false
+			hands, err := d.DealFunc(tt.numPlayers, tt.cardsEach, func(int, Card) { called = true })
+			if err == nil {
+				t.Fatalf("DealFunc(%d, %d, fn) got nil error, want %q", tt.numPlayers, tt.cardsEach, tt.wantErr)
+			}
+			if got, want := err.Error(), tt.wantErr; got != want {
+				t.Errorf("DealFunc(%d, %d, fn) error = %q, want %q", tt.numPlayers, tt.cardsEach, got, want)
+			}
+			if hands != nil {
+				t.Errorf("DealFunc(%d, %d, fn) = %v, want nil", tt.numPlayers, tt.cardsEach, hands)
+			}
+			if called {
+				t.Error("onDeal was called although validation failed")
+			}
+			if got, want := d.Len(), 52; got != want {
+				t.Errorf("After failed DealFunc, deck.Len() = %d, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestDrawWhile(t *testing.T) {
+	isSpade := func(c Card) bool { return c.Suit() == Spades }
+	tests := []struct {
+		name     string
+		pred     func(Card) bool
+		wantN    int
+		wantNext Card
+	}{
+		{"stops before first failing card", isSpade, 13, NewCard(Ace, Hearts)},
+		{"top card fails", func(c Card) bool { return c.Rank() == King }, 0, NewCard(Ace, Spades)},
+		{"every card matches", func(Card) bool { return true }, 52, Card(0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := New()
+			originalCards := d.Cards()
+
+			drawn, err := d.DrawWhile(tt.pred)
+			if err != nil {
+				t.Fatalf("DrawWhile() got error: %v, want nil", err)
+			}
+			if drawn == nil {
+				t.Fatal("DrawWhile() = nil, want non-nil slice")
+			}
+			if !slices.Equal(drawn, originalCards[:tt.wantN]) {
+				t.Errorf("DrawWhile() = %v, want %v", drawn, originalCards[:tt.wantN])
+			}
+			if got, want := d.Len(), 52-tt.wantN; got != want {
+				t.Errorf("After DrawWhile(), deck.Len() = %d, want %d", got, want)
+			}
+			if got := d.PeekOr(Card(0)); got != tt.wantNext {
+				t.Errorf("After DrawWhile(), top card = %v, want %v", got, tt.wantNext)
+			}
+		})
+	}
+}
+
+func TestDrawWhileEmptyDeck(t *testing.T) {
+	d := NewFromCards(nil)
+	drawn, err := d.DrawWhile(func(Card) bool { return true })
+	if !errors.Is(err, ErrInsufficientCards) {
+		t.Errorf("DrawWhile() on empty deck error = %v, want ErrInsufficientCards", err)
+	}
+	if drawn != nil {
+		t.Errorf("DrawWhile() on empty deck = %v, want nil", drawn)
+	}
+}
+
+func TestDrawUntil(t *testing.T) {
+	tests := []struct {
+		name        string
+		pred        func(Card) bool
+		wantN       int
+		wantStopper Card
+	}{
+		{"stops at first match", func(c Card) bool { return c.Suit() == Hearts }, 13, NewCard(Ace, Hearts)},
+		{"top card matches", func(c Card) bool { return c.Rank() == Ace }, 0, NewCard(Ace, Spades)},
+		{"bottom card matches", func(c Card) bool { return c == NewCard(King, Clubs) }, 51, NewCard(King, Clubs)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := New()
+			originalCards := d.Cards()
+
+			drawn, stopper, err := d.DrawUntil(tt.pred)
+			if err != nil {
+				t.Fatalf("DrawUntil() got error: %v, want nil", err)
+			}
+			if drawn == nil {
+				t.Fatal("DrawUntil() drawn = nil, want non-nil slice")
+			}
+			if !slices.Equal(drawn, originalCards[:tt.wantN]) {
+				t.Errorf("DrawUntil() drawn = %v, want %v", drawn, originalCards[:tt.wantN])
+			}
+			if stopper != tt.wantStopper {
+				t.Errorf("DrawUntil() stopper = %v, want %v", stopper, tt.wantStopper)
+			}
+			if !slices.Equal(d.Cards(), originalCards[tt.wantN+1:]) {
+				t.Errorf("After DrawUntil(), deck = %v, want %v", d.Cards(), originalCards[tt.wantN+1:])
+			}
+		})
+	}
+}
+
+func TestDrawUntilNoMatch(t *testing.T) {
+	d := New()
+	drawn, stopper, err := d.DrawUntil(Card.IsJoker)
+	if err == nil {
+		t.Fatal("DrawUntil(IsJoker) got nil error, want error")
+	}
+	if got, want := err.Error(), "no card in deck satisfies the predicate: searched 52 cards"; got != want {
+		t.Errorf("DrawUntil(IsJoker) error = %q, want %q", got, want)
+	}
+	if !errors.Is(err, ErrInsufficientCards) {
+		t.Errorf("DrawUntil(IsJoker) error = %v, want ErrInsufficientCards", err)
+	}
+	if drawn != nil || stopper != Card(0) {
+		t.Errorf("DrawUntil(IsJoker) = %v, %v, want nil, zero Card", drawn, stopper)
+	}
+	if got, want := d.Len(), 52; got != want {
+		t.Errorf("After failed DrawUntil, deck.Len() = %d, want %d", got, want)
+	}
+}
+
+func TestDealMultiDeckLargeHands(t *testing.T) {
+	d, _ := NewMultiple(2)
+	hands, err := d.Deal(1, 104)
+	if err != nil {
+		t.Fatalf("Deal(1, 104) from two decks got error: %v, want nil", err)
+	}
+	if got, want := len(hands[0]), 104; got != want {
+		t.Errorf("Deal(1, 104) hand has %d cards, want %d", got, want)
+	}
+
+	d, _ = NewMultiple(2)
+	sized, err := d.DealHands([]int{60, 44})
+	if err != nil {
+		t.Fatalf("DealHands([60 44]) from two decks got error: %v, want nil", err)
+	}
+	if got, want := len(sized[0]), 60; got != want {
+		t.Errorf("DealHands([60 44])[0] has %d cards, want %d", got, want)
+	}
+
+	d, _ = NewMultiple(2)
+	if _, err := d.Deal(1, 105); !errors.Is(err, ErrInsufficientCards) {
+		t.Errorf("Deal(1, 105) from two decks error = %v, want ErrInsufficientCards", err)
+	}
+
+	d = New()
+	if _, err := d.Deal(math.MaxInt, math.MaxInt); !errors.Is(err, ErrInsufficientCards) {
+		t.Errorf("Deal(MaxInt, MaxInt) error = %v, want ErrInsufficientCards", err)
+	}
+	if got, want := d.Len(), 52; got != want {
+		t.Errorf("After failed Deal, deck.Len() = %d, want %d", got, want)
+	}
+}
+
+func TestDealAllCardsWithJokersToOnePlayer(t *testing.T) {
+	d := NewWithJokers()
+	hands, err := d.Deal(1, 54)
+	if err != nil {
+		t.Fatalf("Deal(1, 54) from a deck with jokers got error: %v, want nil", err)
+	}
+	if !slices.Equal(hands[0], AllCardsWithJokers()) {
+		t.Errorf("Deal(1, 54) = %v, want %v", hands[0], AllCardsWithJokers())
+	}
+	if got, want := NewFromCards(hands[0]).count(Card.IsJoker), 2; got != want {
+		t.Errorf("Deal(1, 54) dealt %d jokers, want %d", got, want)
+	}
+	if !d.IsEmpty() {
+		t.Errorf("After Deal(1, 54), deck.Len() = %d, want 0", d.Len())
+	}
+}
+
+func TestReduce(t *testing.T) {
+	d := New()
+	d.ShuffleWithSeed(7)
+	originalCards := d.Cards()
+
+	// Struct accumulator over the whole deck
+	type tally struct {
+		red, black int
+		last       Card
+	}
+	got := Reduce(d, tally{}, func(acc tally, c Card) tally {
+		if c.Suit() == Hearts || c.Suit() == Diamonds {
+			acc.red++
+		} else {
+			acc.black++
+		}
+		acc.last = c
+		return acc
+	})
+	if want := (tally{26, 26, originalCards[51]}); got != want {
+		t.Errorf("Reduce(tally) = %+v, want %+v", got, want)
+	}
+
+	// Cards are visited from top to bottom
+	order := Reduce(d, []Card(nil), func(acc []Card, c Card) []Card { return append(acc, c) })
+	if !slices.Equal(order, originalCards) {
+		t.Errorf("Reduce visited %v, want %v", order, originalCards)
+	}
+
+	if !slices.Equal(d.Cards(), originalCards) {
+		t.Error("Reduce() modified the deck")
+	}
+
+	empty := NewFromCards(nil)
+	if got, want := Reduce(empty, 42, func(int, Card) int { return 0 }), 42; got != want {
+		t.Errorf("Reduce(empty, 42) = %d, want %d", got, want)
+	}
+}
+
+func TestDealBridge(t *testing.T) {
+	for startSeat := 0; startSeat < 4; startSeat++ {
+		d := New()
+		d.ShuffleWithSeed(int64(startSeat))
+		want, _ := NewFromCards(d.Cards()).DealFrom(startSeat, 4, 13)
+
+		north, east, south, west, err := d.DealBridge(startSeat)
+		if err != nil {
+			t.Fatalf("DealBridge(%d) got error: %v, want nil", startSeat, err)
+		}
+
+		for seat, hand := range [][]Card{north, east, south, west} {
+			if !slices.IsSortedFunc(hand, Card.Compare) {
+				t.Errorf("DealBridge(%d) seat %d hand %v is not sorted", startSeat, seat, hand)
+			}
+			if !NewFromCards(hand).SameMultiset(NewFromCards(want[seat])) {
+				t.Errorf("DealBridge(%d) seat %d = %v, want the cards %v", startSeat, seat, hand, want[seat])
+			}
+		}
+		if !d.IsEmpty() {
+			t.Errorf("After DealBridge(%d), deck.Len() = %d, want 0", startSeat, d.Len())
+		}
+	}
+}
+
+func TestDealBridgeValidation(t *testing.T) {
+	twoDecks, _ := NewMultiple(2)
+	short := New()
+	_, _ = short.Draw()
+
+	tests := []struct {
+		name      string
+		d         *Deck
+		startSeat int
+		wantErr   string
+		wantKind  error
+	}{
+		{"too few cards", short, 0, "insufficient cards: need 52, have 51", ErrInsufficientCards},
+		{"too many cards", twoDecks, 0, "bridge requires exactly 52 cards, have 104", ErrInvalidArgument},
+		{"jokers", NewWithJokers(), 0, "bridge requires exactly 52 cards, have 54", ErrInvalidArgument},
+		{"negative seat", New(), -1, "start player must be between 0 and 3, got -1", ErrInvalidArgument},
+		{"seat out of range", New(), 4, "start player must be between 0 and 3, got 4", ErrInvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := tt.d.Len()
+			north, _, _, _, err := tt.d.DealBridge(tt.startSeat)
+			if err == nil {
+				t.Fatalf("DealBridge(%d) got nil error, want %q", tt.startSeat, tt.wantErr)
+			}
+			if got, want := err.Error(), tt.wantErr; got != want {
+				t.Errorf("DealBridge(%d) error = %q, want %q", tt.startSeat, got, want)
+			}
+			if !errors.Is(err, tt.wantKind) {
+				t.Errorf("DealBridge(%d) error = %v, want %v", tt.startSeat, err, tt.wantKind)
+			}
+			if north != nil {
+				t.Errorf("DealBridge(%d) returned hands along with an error", tt.startSeat)
+			}
+			if got := tt.d.Len(); got != before {
+				t.Errorf("After failed DealBridge, deck.Len() = %d, want %d", got, before)
+			}
+		})
+	}
+}
+
+func TestCanDeal(t *testing.T) {
+	tests := []struct {
+		name          string
+		numPlayers    int
+		cardsEach     int
+		wantRemaining int
+		wantErr       string
+	}{
+		{"poker", 4, 5, 32, ""},
+		{"whole deck", 4, 13, 0, ""},
+		{"zero players", 0, 5, 0, "number of players must be at least 1"},
+		{"zero cards each", 4, 0, 0, "cards per player must be at least 1"},
+		{"insufficient cards", 4, 14, 0, "insufficient cards: need 56, have 52"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := New()
+			remaining, err := d.CanDeal(tt.numPlayers, tt.cardsEach)
+
+			// CanDeal must agree with Deal
+			_, dealErr := New().Deal(tt.numPlayers, tt.cardsEach)
+			if fmt.Sprint(err) != fmt.Sprint(dealErr) {
+				t.Errorf("CanDeal(%d, %d) error = %v, Deal error = %v", tt.numPlayers, tt.cardsEach, err, dealErr)
+			}
+
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("CanDeal(%d, %d) got error: %v, want nil", tt.numPlayers, tt.cardsEach, err)
+				}
+			} else if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("CanDeal(%d, %d) error = %v, want %q", tt.numPlayers, tt.cardsEach, err, tt.wantErr)
+			}
+			if remaining != tt.wantRemaining {
+				t.Errorf("CanDeal(%d, %d) remaining = %d, want %d", tt.numPlayers, tt.cardsEach, remaining, tt.wantRemaining)
+			}
+			if got, want := d.Len(), 52; got != want {
+				t.Errorf("After CanDeal(), deck.Len() = %d, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestClear(t *testing.T) {
+	d := New()
+	capBefore := cap(d.cards)
+
+	d.Clear()
+	if !d.IsEmpty() {
+		t.Errorf("After Clear(), deck.Len() = %d, want 0", d.Len())
+	}
+	if got := cap(d.cards); got != capBefore {
+		t.Errorf("After Clear(), capacity = %d, want %d", got, capBefore)
+	}
+
+	if allocs := testing.AllocsPerRun(10, func() {
+		d.Clear()
+		for i := 0; i < 52; i++ {
+			d.Add(NewCard(Ace, Spades))
+		}
+	}); allocs != 0 {
+		t.Errorf("Refilling a cleared deck allocated %v times, want 0", allocs)
+	}
+}
+
+func TestReset(t *testing.T) {
+	tests := []struct {
+		name string
+		d    func() *Deck
+	}{
+		{"shuffled", func() *Deck { d := New(); d.ShuffleWithSeed(3); return d }},
+		{"partially drawn", func() *Deck { d := New(); _, _ = d.DrawN(30); return d }},
+		{"empty", func() *Deck { d := New(); d.Clear(); return d }},
+		{"with jokers", NewWithJokers},
+		{"multiple decks", func() *Deck { d, _ := NewMultiple(3); return d }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := tt.d()
+			d.Reset()
+			if !slices.Equal(d.Cards(), AllCards()) {
+				t.Errorf("After Reset(), deck = %v, want a new sorted deck", d)
+			}
+		})
+	}
+
+	d := New()
+	d.ShuffleWithSeed(5)
+	if allocs := testing.AllocsPerRun(10, d.Reset); allocs != 0 {
+		t.Errorf("Reset() on a full deck allocated %v times, want 0", allocs)
+	}
+}
+
+func TestDealMatching(t *testing.T) {
+	isEven := func(c Card) bool { return !c.IsJoker() && c.Rank()%2 == 0 }
+
+	d := New()
+	hands, skipped, err := d.DealMatching(2, 6, isEven)
+	if err != nil {
+		t.Fatalf("DealMatching(2, 6, isEven) got error: %v, want nil", err)
+	}
+
+	// Spades and Hearts hold exactly 12 even cards, ending with the Queen of Hearts
+	wantHands := [][]Card{
+		{NewCard(Two, Spades), NewCard(Four, Spades), NewCard(Six, Spades), NewCard(Eight, Spades), NewCard(Ten, Spades), NewCard(Queen, Spades)},
+		{NewCard(Two, Hearts), NewCard(Four, Hearts), NewCard(Six, Hearts), NewCard(Eight, Hearts), NewCard(Ten, Hearts), NewCard(Queen, Hearts)},
+	}
+	for i := range wantHands {
+		if !slices.Equal(hands[i], wantHands[i]) {
+			t.Errorf("DealMatching(2, 6, isEven)[%d] = %v, want %v", i, hands[i], wantHands[i])
+		}
+	}
+
+	wantSkipped := []Card{
+		NewCard(Ace, Spades), NewCard(Three, Spades), NewCard(Five, Spades), NewCard(Seven, Spades),
+		NewCard(Nine, Spades), NewCard(Jack, Spades), NewCard(King, Spades),
+		NewCard(Ace, Hearts), NewCard(Three, Hearts), NewCard(Five, Hearts), NewCard(Seven, Hearts),
+		NewCard(Nine, Hearts), NewCard(Jack, Hearts),
+	}
+	if !slices.Equal(skipped, wantSkipped) {
+		t.Errorf("DealMatching(2, 6, isEven) skipped = %v, want %v", skipped, wantSkipped)
+	}
+
+	// The King of Hearts follows the last dealt card and stays in the deck
+	if got, want := d.Len(), 52-25; got != want {
+		t.Errorf("After DealMatching(2, 6, isEven), deck.Len() = %d, want %d", got, want)
+	}
+	if got, want := d.PeekOr(Card(0)), NewCard(King, Hearts); got != want {
+		t.Errorf("After DealMatching(2, 6, isEven), top card = %v, want %v", got, want)
+	}
+
+	// Hands do not share capacity
+	hands[0] = append(hands[0], NewRedJoker())
+	if got, want := hands[1][0], NewCard(Two, Hearts); got != want {
+		t.Errorf("Appending to hands[0] changed hands[1][0] to %v, want %v", got, want)
+	}
+}
+
+func TestDealMatchingValidation(t *testing.T) {
+	isAce := func(c Card) bool { return c.Rank() == Ace }
+	tests := []struct {
+		name       string
+		numPlayers int
+		cardsEach  int
+		wantErr    string
+	}{
+		{"zero players", 0, 1, "number of players must be at least 1"},
+		{"zero cards each", 2, 0, "cards per player must be at least 1"},
+		{"insufficient matching cards", 5, 1, "insufficient matching cards: need 5, have 4"},
+		{"insufficient cards", 4, 14, "insufficient cards: need 56, have 52"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := New()
+			hands, skipped, err := d.DealMatching(tt.numPlayers, tt.cardsEach, isAce)
+			if err == nil {
+				t.Fatalf("DealMatching(%d, %d, isAce) got nil error, want %q", tt.numPlayers, tt.cardsEach, tt.wantErr)
+			}
+			if got, want := err.Error(), tt.wantErr; got != want {
+				t.Errorf("DealMatching(%d, %d, isAce) error = %q, want %q", tt.numPlayers, tt.cardsEach, got, want)
+			}
+			if hands != nil || skipped != nil {
+				t.Errorf("DealMatching(%d, %d, isAce) returned cards along with an error", tt.numPlayers, tt.cardsEach)
+			}
+			if got, want := d.Len(), 52; got != want {
+				t.Errorf("After failed DealMatching, deck.Len() = %d, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestToIDs(t *testing.T) {
+	d := NewWithJokers()
+	d.ShuffleWithSeed(17)
+
+	ids := d.ToIDs()
+	for i, card := range d.Cards() {
+		if got, want := ids[i], int32(card.Ordinal()); got != want {
+			t.Errorf("ToIDs()[%d] = %d, want %d", i, got, want)
+		}
+	}
+
+	d2, err := DeckFromIDs(ids)
+	if err != nil {
+		t.Fatalf("DeckFromIDs(ToIDs()) got error: %v, want nil", err)
+	}
+	if !slices.Equal(d2.Cards(), d.Cards()) {
+		t.Errorf("DeckFromIDs(ToIDs()) = %v, want %v", d2, d)
+	}
+
+	if got := NewFromCards([]Card{Card(0)}).ToIDs(); !slices.Equal(got, []int32{-1}) {
+		t.Errorf("ToIDs() of an invalid card = %v, want [-1]", got)
+	}
+
+	empty, err := DeckFromIDs(nil)
+	if err != nil || !empty.IsEmpty() {
+		t.Errorf("DeckFromIDs(nil) = %v, %v, want empty deck, nil", empty, err)
+	}
+}
+
+func TestDeckFromIDsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		ids     []int32
+		wantErr string
+	}{
+		{"negative", []int32{0, -1}, "invalid card ID at index 1: ordinal out of range: -1"},
+		{"too large", []int32{54}, "invalid card ID at index 0: ordinal out of range: 54"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := DeckFromIDs(tt.ids)
+			if err == nil {
+				t.Fatalf("DeckFromIDs(%v) got nil error, want %q", tt.ids, tt.wantErr)
+			}
+			if got, want := err.Error(), tt.wantErr; got != want {
+				t.Errorf("DeckFromIDs(%v) error = %q, want %q", tt.ids, got, want)
+			}
+			if d != nil {
+				t.Errorf("DeckFromIDs(%v) = %v, want nil", tt.ids, d)
+			}
+		})
+	}
+}
+
+func TestDealSkipping(t *testing.T) {
+	d := New()
+	hands, err := d.DealSkipping(2, []bool{true, false, true, true, false})
+	if err != nil {
+		t.Fatalf("DealSkipping(2, active) got error: %v, want nil", err)
+	}
+
+	want := [][]Card{
+		{NewCard(Ace, Spades), NewCard(Four, Spades)},
+		nil,
+		{NewCard(Two, Spades), NewCard(Five, Spades)},
+		{NewCard(Three, Spades), NewCard(Six, Spades)},
+		nil,
+	}
+	if got, want := len(hands), len(want); got != want {
+		t.Fatalf("DealSkipping(2, active) = %d hands, want %d", got, want)
+	}
+	for seat := range want {
+		if !slices.Equal(hands[seat], want[seat]) || (hands[seat] == nil) != (want[seat] == nil) {
+			t.Errorf("DealSkipping(2, active)[%d] = %v, want %v", seat, hands[seat], want[seat])
+		}
+	}
+
+	if got, want := d.Len(), 52-6; got != want {
+		t.Errorf("After DealSkipping(2, active), deck.Len() = %d, want %d", got, want)
+	}
+
+	// With every seat active it matches DealFrom(0, ...)
+	all, _ := New().DealSkipping(3, []bool{true, true, true, true})
+	from, _ := New().DealFrom(0, 4, 3)
+	for seat := range from {
+		if !slices.Equal(all[seat], from[seat]) {
+			t.Errorf("DealSkipping(3, all active)[%d] = %v, want %v", seat, all[seat], from[seat])
+		}
+	}
+}
+
+func TestDealSkippingValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		cardsEach int
+		active    []bool
+		wantErr   string
+	}{
+		{"no seats", 5, nil, "at least one seat must be active"},
+		{"no active seats", 5, []bool{false, false}, "at least one seat must be active"},
+		{"zero cards each", 0, []bool{true, true}, "cards per player must be at least 1"},
+		{"insufficient cards", 27, []bool{true, false, true}, "insufficient cards: need 54, have 52"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := New()
+			hands, err := d.DealSkipping(tt.cardsEach, tt.active)
+			if err == nil {
+				t.Fatalf("DealSkipping(%d, %v) got nil error, want %q", tt.cardsEach, tt.active, tt.wantErr)
+			}
+			if got, want := err.Error(), tt.wantErr; got != want {
+				t.Errorf("DealSkipping(%d, %v) error = %q, want %q", tt.cardsEach, tt.active, got, want)
+			}
+			if !errors.Is(err, ErrInvalidArgument) && !errors.Is(err, ErrInsufficientCards) {
+				t.Errorf("DealSkipping(%d, %v) error = %v is not classified", tt.cardsEach, tt.active, err)
+			}
+			if hands != nil {
+				t.Errorf("DealSkipping(%d, %v) = %v, want nil", tt.cardsEach, tt.active, hands)
+			}
+			if got, want := d.Len(), 52; got != want {
+				t.Errorf("After failed DealSkipping, deck.Len() = %d, want %d", got, want)
+			}
+		})
+	}
+}
+
+// naiveShuffler swaps every position with a uniformly random position, a
+// common mistake that favours some permutations over others.
+type naiveShuffler struct {
+	rng *mathrand.Rand
+}
+
+func (s naiveShuffler) Shuffle(n int, swap func(i, j int)) {
+	for i := 0; i < n; i++ {
+		swap(i, s.rng.Intn(n))
+	}
+}
+
+func TestShuffleUniformityTest(t *testing.T) {
+	const deckSize, trials = 8, 40000
+
+	if got := ShuffleUniformityTest(NewStableShuffler(1), deckSize, trials); got < 0.5 || got > 1.5 {
+		t.Errorf("ShuffleUniformityTest(StableShuffler) = %.3f, want about 1", got)
+	}
+	if got := ShuffleUniformityTest(NewSeededShuffler(1), deckSize, trials); got < 0.5 || got > 1.5 {
+		t.Errorf("ShuffleUniformityTest(DefaultShuffler) = %.3f, want about 1", got)
+	}
+
+	naive := naiveShuffler{rng: mathrand.New(mathrand.NewSource(1))}
+	if got := ShuffleUniformityTest(naive, deckSize, trials); got < 10 {
+		t.Errorf("ShuffleUniformityTest(naive) = %.3f, want a clearly biased result above 10", got)
+	}
+
+	// A shuffler that never moves anything is maximally biased
+	identity := NewWeightedShuffler(1, func(i int) float64 { return math.Pow(1e6, float64(deckSize-i)) })
+	if got := ShuffleUniformityTest(identity, deckSize, 100); got < 10 {
+		t.Errorf("ShuffleUniformityTest(identity) = %.3f, want a large value", got)
+	}
+}
+
+func TestShuffleUniformityTestInvalid(t *testing.T) {
+	tests := []struct {
+		name             string
+		deckSize, trials int
+	}{
+		{"single card", 1, 100},
+		{"no trials", 52, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ShuffleUniformityTest(SecureShuffler{}, tt.deckSize, tt.trials); !math.IsNaN(got) {
+				t.Errorf("ShuffleUniformityTest(%d, %d) = %v, want NaN", tt.deckSize, tt.trials, got)
+			}
+		})
+	}
+}
+
+func TestDealSorted(t *testing.T) {
+	d := New()
+	d.ShuffleWithSeed(21)
+	want, _ := NewFromCards(d.Cards()).Deal(4, 13)
+
+	hands, err := d.DealSorted(4, 13)
+	if err != nil {
+		t.Fatalf("DealSorted(4, 13) got error: %v, want nil", err)
+	}
+	for i, hand := range hands {
+		if !slices.IsSortedFunc(hand, Card.Compare) {
+			t.Errorf("DealSorted(4, 13)[%d] = %v is not sorted", i, hand)
+		}
+		if !NewFromCards(hand).SameMultiset(NewFromCards(want[i])) {
+			t.Errorf("DealSorted(4, 13)[%d] = %v, want the cards %v", i, hand, want[i])
+		}
+	}
+	if !d.IsEmpty() {
+		t.Errorf("After DealSorted(4, 13), deck.Len() = %d, want 0", d.Len())
+	}
+}
+
+func TestDealSortedAceHigh(t *testing.T) {
+	d := NewFromCards([]Card{
+		NewBlackJoker(), NewCard(Ace, Hearts), NewCard(King, Hearts), NewCard(Two, Spades),
+		NewCard(Ace, Spades), NewRedJoker(), NewCard(Two, Hearts),
+	})
+
+	hands, err := d.DealSortedAceHigh(1, 7)
+	if err != nil {
+		t.Fatalf("DealSortedAceHigh(1, 7) got error: %v, want nil", err)
+	}
+	want := []Card{
+		NewCard(Two, Spades), NewCard(Ace, Spades),
+		NewCard(Two, Hearts), NewCard(King, Hearts), NewCard(Ace, Hearts),
+		NewRedJoker(), NewBlackJoker(),
+	}
+	if !slices.Equal(hands[0], want) {
+		t.Errorf("DealSortedAceHigh(1, 7) = %v, want %v", hands[0], want)
+	}
+}
+
+func TestDealSortedValidation(t *testing.T) {
+	for name, deal := range map[string]func(d *Deck) ([][]Card, error){
+		"DealSorted":        func(d *Deck) ([][]Card, error) { return d.DealSorted(4, 14) },
+		"DealSortedAceHigh": func(d *Deck) ([][]Card, error) { return d.DealSortedAceHigh(4, 14) },
+	} {
+		d := New()
+		hands, err := deal(d)
+		if err == nil {
+			t.Fatalf("%s(4, 14) got nil error, want error", name)
+		}
+		if got, want := err.Error(), "insufficient cards: need 56, have 52"; got != want {
+			t.Errorf("%s(4, 14) error = %q, want %q", name, got, want)
+		}
+		if hands != nil {
+			t.Errorf("%s(4, 14) = %v, want nil", name, hands)
+		}
+		if got, want := d.Len(), 52; got != want {
+			t.Errorf("After failed %s, deck.Len() = %d, want %d", name, got, want)
+		}
+	}
+}
+
+// slowShuffler is a ContextShuffler that performs one swap per step and
+// checks for cancellation between steps, like a remote entropy source.
+type slowShuffler struct {
+	cancelAfter int
+	cancel      context.CancelFunc
+}
+
+func (s slowShuffler) Shuffle(n int, swap func(i, j int)) {
+	_ = s.ShuffleContext(context.Background(), n, swap)
+}
+
+func (s slowShuffler) ShuffleContext(ctx context.Context, n int, swap func(i, j int)) error {
+	for i := n - 1; i > 0; i-- {
+		if n-1-i == s.cancelAfter && s.cancel != nil {
+			s.cancel()
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		swap(i, 0)
+	}
+	return nil
+}
+
+func TestShuffleContext(t *testing.T) {
+	d := New()
+	if err := d.ShuffleContext(context.Background(), slowShuffler{}); err != nil {
+		t.Fatalf("ShuffleContext() got error: %v, want nil", err)
+	}
+	want := New()
+	want.ShuffleWith(slowShuffler{})
+	if !slices.Equal(d.Cards(), want.Cards()) {
+		t.Errorf("ShuffleContext() = %v, want %v", d, want)
+	}
+
+	// A plain Shuffler is used through Shuffle
+	d = New()
+	if err := d.ShuffleContext(context.Background(), NewSeededShuffler(4)); err != nil {
+		t.Fatalf("ShuffleContext(DefaultShuffler) got error: %v, want nil", err)
+	}
+	want = New()
+	want.ShuffleWith(NewSeededShuffler(4))
+	if !slices.Equal(d.Cards(), want.Cards()) {
+		t.Errorf("ShuffleContext(DefaultShuffler) = %v, want %v", d, want)
+	}
+}
+
+func TestShuffleContextCancelled(t *testing.T) {
+	// Cancelled midway through the shuffle
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	d := New()
+	err := d.ShuffleContext(ctx, slowShuffler{cancelAfter: 10, cancel: cancel})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("ShuffleContext() error = %v, want context.Canceled", err)
+	}
+	if !slices.Equal(d.Cards(), AllCards()) {
+		t.Errorf("After cancelled ShuffleContext(), deck = %v, want unchanged", d)
+	}
+
+	// Already cancelled, with a shuffler that does not support contexts
+	d = New()
+	if err := d.ShuffleContext(ctx, NewSeededShuffler(1)); !errors.Is(err, context.Canceled) {
+		t.Errorf("ShuffleContext(cancelled ctx) error = %v, want context.Canceled", err)
+	}
+	if !slices.Equal(d.Cards(), AllCards()) {
+		t.Errorf("After ShuffleContext(cancelled ctx), deck = %v, want unchanged", d)
+	}
+}
+
+func TestDrawContext(t *testing.T) {
+	d := New()
+	card, err := d.DrawContext(context.Background())
+	if err != nil {
+		t.Fatalf("DrawContext() got error: %v, want nil", err)
+	}
+	if got, want := card, NewCard(Ace, Spades); got != want {
+		t.Errorf("DrawContext() = %v, want %v", got, want)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := d.DrawContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("DrawContext(cancelled ctx) error = %v, want context.Canceled", err)
+	}
+	if got, want := d.Len(), 51; got != want {
+		t.Errorf("After DrawContext(cancelled ctx), deck.Len() = %d, want %d", got, want)
+	}
+
+	if _, err := NewFromCards(nil).DrawContext(context.Background()); !errors.Is(err, ErrInsufficientCards) {
+		t.Errorf("DrawContext() on empty deck error = %v, want ErrInsufficientCards", err)
+	}
+}
+
+func TestDealHandsLargerThanStandardDeck(t *testing.T) {
+	d, _ := NewMultiple(2)
+	hands, err := d.DealHands([]int{60})
+	if err != nil {
+		t.Fatalf("DealHands([60]) from two decks got error: %v, want nil", err)
+	}
+	if got, want := len(hands[0]), 60; got != want {
+		t.Errorf("DealHands([60]) hand has %d cards, want %d", got, want)
+	}
+
+	d, _ = NewMultiple(2)
+	_, err = d.DealHands([]int{2, 105})
+	if err == nil {
+		t.Fatal("DealHands([2 105]) from two decks got nil error, want error")
+	}
+	if got, want := err.Error(), "hand size (105) at index 1 exceeds deck size of 104"; got != want {
+		t.Errorf("DealHands([2 105]) error = %q, want %q", got, want)
+	}
+	if !errors.Is(err, ErrInsufficientCards) {
+		t.Errorf("DealHands([2 105]) error = %v, want ErrInsufficientCards", err)
+	}
+}
+
+func TestInsertAll(t *testing.T) {
+	batch := []Card{NewRedJoker(), NewBlackJoker(), NewCard(Ace, Clubs)}
+	tests := []struct {
+		name  string
+		index int
+	}{
+		{"top", 0},
+		{"middle", 5},
+		{"bottom", 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewFromCards(AllCards()[:10])
+			before := d.Cards()
+
+			if err := d.InsertAll(tt.index, batch); err != nil {
+				t.Fatalf("InsertAll(%d, batch) got error: %v, want nil", tt.index, err)
+			}
+			want := slices.Concat(before[:tt.index], batch, before[tt.index:])
+			if !slices.Equal(d.Cards(), want) {
+				t.Errorf("After InsertAll(%d, batch), deck = %v, want %v", tt.index, d.Cards(), want)
+			}
+		})
+	}
+
+	// Reassembling a cut restores the original order
+	d := New()
+	top, _ := d.DrawN(20)
+	if err := d.InsertAll(0, top); err != nil {
+		t.Fatalf("InsertAll(0, top) got error: %v, want nil", err)
+	}
+	if !slices.Equal(d.Cards(), AllCards()) {
+		t.Errorf("After reassembling a cut, deck = %v, want the original order", d)
+	}
+
+	// Inserting nothing is a no-op
+	if err := d.InsertAll(3, nil); err != nil || !slices.Equal(d.Cards(), AllCards()) {
+		t.Errorf("InsertAll(3, nil) = %v, deck = %v, want nil and an unchanged deck", err, d)
+	}
+
+	// The inserted cards do not alias the argument
+	top[0] = NewRedJoker()
+	if got, want := d.Cards()[0], NewCard(Ace, Spades); got != want {
+		t.Errorf("After modifying the inserted slice, deck[0] = %v, want %v", got, want)
+	}
+}
+
+func TestInsertAllOutOfRange(t *testing.T) {
+	for _, index := range []int{-1, 53} {
+		d := New()
+		err := d.InsertAll(index, []Card{NewRedJoker()})
+		if err == nil {
+			t.Fatalf("InsertAll(%d, cards) got nil error, want error", index)
+		}
+		if got, want := err.Error(), fmt.Sprintf("index out of range: %d (deck has 52 cards)", index); got != want {
+			t.Errorf("InsertAll(%d, cards) error = %q, want %q", index, got, want)
+		}
+		if !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("InsertAll(%d, cards) error = %v, want ErrInvalidArgument", index, err)
+		}
+		if !slices.Equal(d.Cards(), AllCards()) {
+			t.Errorf("After failed InsertAll(%d, cards), deck = %v, want unchanged", index, d)
+		}
+	}
+}
+
+func TestDrawOrReshuffle(t *testing.T) {
+	// A non-empty deck draws normally and leaves the discard pile alone
+	d := New()
+	discard := NewFromCards([]Card{NewCard(Two, Clubs), NewCard(Three, Clubs)})
+	card, err := d.DrawOrReshuffle(discard, NewSeededShuffler(1))
+	if err != nil {
+		t.Fatalf("DrawOrReshuffle() got error: %v, want nil", err)
+	}
+	if got, want := card, NewCard(Ace, Spades); got != want {
+		t.Errorf("DrawOrReshuffle() = %v, want %v", got, want)
+	}
+	if got, want := discard.Len(), 2; got != want {
+		t.Errorf("After DrawOrReshuffle(), discard.Len() = %d, want %d", got, want)
+	}
+
+	// An empty deck takes the shuffled discard pile first
+	d = NewFromCards(nil)
+	discard = New()
+	want := New()
+	want.ShuffleWith(NewSeededShuffler(2))
+
+	card, err = d.DrawOrReshuffle(discard, NewSeededShuffler(2))
+	if err != nil {
+		t.Fatalf("DrawOrReshuffle() from empty deck got error: %v, want nil", err)
+	}
+	if got, want := card, want.Cards()[0]; got != want {
+		t.Errorf("DrawOrReshuffle() from empty deck = %v, want %v", got, want)
+	}
+	if !slices.Equal(d.Cards(), want.Cards()[1:]) {
+		t.Errorf("After DrawOrReshuffle(), deck = %v, want %v", d, want.Cards()[1:])
+	}
+	if !discard.IsEmpty() {
+		t.Errorf("After DrawOrReshuffle(), discard.Len() = %d, want 0", discard.Len())
+	}
+
+	// The discard pile can be reused without affecting the deck
+	discard.Add(NewRedJoker())
+	if got, want := d.Len(), 51; got != want {
+		t.Errorf("After adding to discard, deck.Len() = %d, want %d", got, want)
+	}
+}
+
+func TestDrawOrReshuffleBothEmpty(t *testing.T) {
+	tests := []struct {
+		name    string
+		discard *Deck
+	}{
+		{"empty discard", NewFromCards(nil)},
+		{"nil discard", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewFromCards(nil)
+			_, err := d.DrawOrReshuffle(tt.discard, SecureShuffler{})
+			if err == nil {
+				t.Fatal("DrawOrReshuffle() with both piles empty got nil error, want error")
+			}
+			if got, want := err.Error(), "cannot draw: deck and discard pile are empty"; got != want {
+				t.Errorf("DrawOrReshuffle() error = %q, want %q", got, want)
+			}
+			if !errors.Is(err, ErrInsufficientCards) {
+				t.Errorf("DrawOrReshuffle() error = %v, want ErrInsufficientCards", err)
+			}
+		})
+	}
+
+	// A nil discard is not touched while the deck still has cards
+	d := New()
+	if card, err := d.DrawOrReshuffle(nil, SecureShuffler{}); err != nil || card != NewCard(Ace, Spades) {
+		t.Errorf("DrawOrReshuffle(nil) = %v, %v, want %v, nil", card, err, NewCard(Ace, Spades))
+	}
+}
+
+func TestCompareTrick(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    Card
+		lead    Suit
+		trump   Suit
+		aceHigh bool
+		want    int
+	}{
+		{"higher card of lead suit wins", NewCard(King, Hearts), NewCard(Ten, Hearts), Hearts, Spades, true, 1},
+		{"lower card of lead suit loses", NewCard(Ten, Hearts), NewCard(King, Hearts), Hearts, Spades, true, -1},
+		{"ace high", NewCard(Ace, Hearts), NewCard(King, Hearts), Hearts, Spades, true, 1},
+		{"ace low", NewCard(Ace, Hearts), NewCard(Two, Hearts), Hearts, Spades, false, -1},
+		{"lead suit beats off suit", NewCard(Two, Hearts), NewCard(Ace, Clubs), Hearts, Spades, true, 1},
+		{"off suit loses to lead suit", NewCard(Ace, Clubs), NewCard(Two, Hearts), Hearts, Spades, true, -1},
+		{"trump beats lead suit", NewCard(Two, Spades), NewCard(Ace, Hearts), Hearts, Spades, true, 1},
+		{"higher trump wins", NewCard(Three, Spades), NewCard(Two, Spades), Hearts, Spades, true, 1},
+		{"trump beats off suit", NewCard(Two, Spades), NewCard(Ace, Diamonds), Hearts, Spades, true, 1},
+		{"two off-suit cards cannot win", NewCard(Ace, Clubs), NewCard(Two, Diamonds), Hearts, Spades, true, 0},
+		{"same card", NewCard(Queen, Hearts), NewCard(Queen, Hearts), Hearts, Spades, true, 0},
+		{"trump led", NewCard(Two, Spades), NewCard(Ace, Hearts), Spades, Spades, true, 1},
+		{"no trump", NewCard(Two, Hearts), NewCard(Ace, Spades), Hearts, Hearts, true, 1},
+		{"joker never wins", NewRedJoker(), NewCard(Two, Hearts), Hearts, Hearts, true, -1},
+		{"joker against off suit", NewBlackJoker(), NewCard(Two, Clubs), Hearts, Spades, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CompareTrick(tt.a, tt.b, tt.lead, tt.trump, tt.aceHigh); got != tt.want {
+				t.Errorf("CompareTrick(%v, %v, %v, %v, %v) = %d, want %d", tt.a, tt.b, tt.lead, tt.trump, tt.aceHigh, got, tt.want)
+			}
+			// Swapping the cards flips the result
+			if got := CompareTrick(tt.b, tt.a, tt.lead, tt.trump, tt.aceHigh); got != -tt.want {
+				t.Errorf("CompareTrick(%v, %v, %v, %v, %v) = %d, want %d", tt.b, tt.a, tt.lead, tt.trump, tt.aceHigh, got, -tt.want)
+			}
+		})
+	}
+}
+
+func TestTable(t *testing.T) {
+	d := New()
+	table, err := NewTable(d, 3)
+	if err != nil {
+		t.Fatalf("NewTable(d, 3) got error: %v, want nil", err)
+	}
+	if table.Deck() != d {
+		t.Error("table.Deck() does not return the deck passed to NewTable")
+	}
+
+	for round, wantDealer := range []int{1, 2, 0, 1} {
+		d.Reset()
+		top := d.PeekOr(Card(0))
+		before := table.Dealer()
+
+		hands, err := table.Deal(2)
+		if err != nil {
+			t.Fatalf("round %d: Deal(2) got error: %v, want nil", round, err)
+		}
+		if got := table.Dealer(); got != wantDealer {
+			t.Errorf("round %d: after Deal(2), Dealer() = %d, want %d", round, got, wantDealer)
+		}
+		// The seat left of the previous dealer receives the top card
+		if got := hands[(before+1)%3][0]; got != top {
+			t.Errorf("round %d: seat %d first card = %v, want the top card %v", round, (before+1)%3, got, top)
+		}
+	}
+}
+
+func TestTableSetDealer(t *testing.T) {
+	table, _ := NewTable(New(), 4)
+	if err := table.SetDealer(3); err != nil {
+		t.Fatalf("SetDealer(3) got error: %v, want nil", err)
+	}
+	hands, _ := table.Deal(1)
+	if got, want := hands[0][0], NewCard(Ace, Spades); got != want {
+		t.Errorf("After SetDealer(3), seat 0 first card = %v, want %v", got, want)
+	}
+
+	for _, seat := range []int{-1, 4} {
+		err := table.SetDealer(seat)
+		if err == nil {
+			t.Fatalf("SetDealer(%d) got nil error, want error", seat)
+		}
+		if got, want := err.Error(), fmt.Sprintf("dealer seat must be between 0 and 3, got %d", seat); got != want {
+			t.Errorf("SetDealer(%d) error = %q, want %q", seat, got, want)
+		}
+		if got, want := table.Dealer(), 0; got != want {
+			t.Errorf("After failed SetDealer(%d), Dealer() = %d, want %d", seat, got, want)
+		}
+	}
+}
+
+func TestTableErrors(t *testing.T) {
+	if _, err := NewTable(New(), 0); err == nil || err.Error() != "number of players must be at least 1" {
+		t.Errorf("NewTable(d, 0) error = %v, want %q", err, "number of players must be at least 1")
+	}
+
+	d := New()
+	table, _ := NewTable(d, 4)
+	hands, err := table.Deal(14)
+	if !errors.Is(err, ErrInsufficientCards) {
+		t.Errorf("Deal(14) error = %v, want ErrInsufficientCards", err)
+	}
+	if hands != nil {
+		t.Errorf("Deal(14) = %v, want nil", hands)
+	}
+	if got, want := table.Dealer(), 0; got != want {
+		t.Errorf("After failed Deal, Dealer() = %d, want %d", got, want)
+	}
+	if got, want := d.Len(), 52; got != want {
+		t.Errorf("After failed Deal, deck.Len() = %d, want %d", got, want)
+	}
+}
+
+func TestDuplicates(t *testing.T) {
+	aceSpades, kingHearts := NewCard(Ace, Spades), NewCard(King, Hearts)
+
+	if got := New().Duplicates(); got != nil {
+		t.Errorf("New().Duplicates() = %v, want nil", got)
+	}
+
+	d := New()
+	d.Add(kingHearts)
+	d.Add(aceSpades)
+	d.Add(kingHearts)
+	if got, want := d.Duplicates(), []Card{kingHearts, aceSpades}; !slices.Equal(got, want) {
+		t.Errorf("Duplicates() = %v, want %v", got, want)
+	}
+
+	if got := NewFromCards(nil).Duplicates(); got != nil {
+		t.Errorf("Duplicates() on empty deck = %v, want nil", got)
+	}
+}
+
+func TestDuplicatesN(t *testing.T) {
+	shoe, _ := NewMultiple(2)
+	if got := shoe.DuplicatesN(2); got != nil {
+		t.Errorf("DuplicatesN(2) on a two-deck shoe = %v, want nil", got)
+	}
+	if got, want := len(shoe.DuplicatesN(1)), 52; got != want {
+		t.Errorf("DuplicatesN(1) on a two-deck shoe reported %d cards, want %d", got, want)
+	}
+
+	extra := NewCard(Seven, Diamonds)
+	shoe.AddToTop(extra)
+	if got, want := shoe.DuplicatesN(2), []Card{extra}; !slices.Equal(got, want) {
+		t.Errorf("DuplicatesN(2) with an extra card = %v, want %v", got, want)
+	}
+
+	small := NewFromCards([]Card{NewRedJoker(), NewCard(Two, Clubs), NewRedJoker()})
+	for _, multiplicity := range []int{0, -1} {
+		if got, want := small.DuplicatesN(multiplicity), []Card{NewRedJoker(), NewCard(Two, Clubs)}; !slices.Equal(got, want) {
+			t.Errorf("DuplicatesN(%d) = %v, want %v", multiplicity, got, want)
+		}
+	}
+}
+
+func TestScriptedShuffler(t *testing.T) {
+	d := New()
+	target := New()
+	target.ShuffleWithSeed(99)
+	reversed := d.Cards()
+	slices.Reverse(reversed)
+
+	first, err := PermutationTo(d.Cards(), target.Cards())
+	if err != nil {
+		t.Fatalf("PermutationTo() got error: %v, want nil", err)
+	}
+	second, _ := PermutationTo(target.Cards(), reversed)
+
+	s, err := NewScriptedShuffler(NewSeededShuffler(5), first, second)
+	if err != nil {
+		t.Fatalf("NewScriptedShuffler() got error: %v, want nil", err)
+	}
+	first[0], first[1] = first[1], first[0] // the script is copied
+	if got, want := s.Remaining(), 2; got != want {
+		t.Errorf("Remaining() = %d, want %d", got, want)
+	}
+
+	d.ShuffleWith(s)
+	if !slices.Equal(d.Cards(), target.Cards()) {
+		t.Errorf("First scripted shuffle = %v, want %v", d, target)
+	}
+	d.ShuffleWith(s)
+	if !slices.Equal(d.Cards(), reversed) {
+		t.Errorf("Second scripted shuffle = %v, want %v", d, reversed)
+	}
+	if got, want := s.Remaining(), 0; got != want {
+		t.Errorf("Remaining() = %d, want %d", got, want)
+	}
+
+	// Then it falls back to the wrapped shuffler
+	want := NewFromCards(d.Cards())
+	want.ShuffleWith(NewSeededShuffler(5))
+	d.ShuffleWith(s)
+	if !slices.Equal(d.Cards(), want.Cards()) {
+		t.Errorf("Shuffle after the script = %v, want %v", d, want)
+	}
+}
+
+func TestScriptedShufflerErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  [][]int
+		wantErr string
+	}{
+		{"duplicate index", [][]int{{0, 1}, {1, 1}}, "script entry 1: invalid permutation: duplicate index 1 at position 1"},
+		{"out of range", [][]int{{0, 2}}, "script entry 0: invalid permutation: index 2 out of range at position 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewScriptedShuffler(SecureShuffler{}, tt.script...)
+			if err == nil {
+				t.Fatalf("NewScriptedShuffler(%v) got nil error, want %q", tt.script, tt.wantErr)
+			}
+			if got, want := err.Error(), tt.wantErr; got != want {
+				t.Errorf("NewScriptedShuffler(%v) error = %q, want %q", tt.script, got, want)
+			}
+			if s != nil {
+				t.Errorf("NewScriptedShuffler(%v) = %v, want nil", tt.script, s)
+			}
+		})
+	}
+
+	s, _ := NewScriptedShuffler(SecureShuffler{}, []int{1, 0})
+	defer func() {
+		if got, want := recover(), "scripted permutation has length 2, want 52"; got != want {
+			t.Errorf("Shuffle with a mismatched permutation panicked with %v, want %q", got, want)
+		}
+	}()
+	New().ShuffleWith(s)
+}
+
+func TestPermutationTo(t *testing.T) {
+	ace, king := NewCard(Ace, Spades), NewCard(King, Spades)
+	from := []Card{ace, king, ace}
+	to := []Card{ace, ace, king}
+
+	perm, err := PermutationTo(from, to)
+	if err != nil {
+		t.Fatalf("PermutationTo() got error: %v, want nil", err)
+	}
+	if want := []int{0, 2, 1}; !slices.Equal(perm, want) {
+		t.Errorf("PermutationTo() = %v, want %v", perm, want)
+	}
+
+	d := NewFromCards(from)
+	if err := d.ApplyPermutation(perm); err != nil || !slices.Equal(d.Cards(), to) {
+		t.Errorf("ApplyPermutation(PermutationTo()) = %v, %v, want %v", d, err, to)
+	}
+
+	if _, err := PermutationTo(from, to[:2]); err == nil || err.Error() != "cannot rearrange 3 cards into 2 cards" {
+		t.Errorf("PermutationTo() with different lengths error = %v", err)
+	}
+	if _, err := PermutationTo(from, []Card{ace, king, king}); err == nil || err.Error() != "card King of Spades at index 2 is not available in the source order" {
+		t.Errorf("PermutationTo() with an extra copy error = %v", err)
+	}
+}
+
+func TestHandSet(t *testing.T) {
+	d := New()
+	hands, err := d.DealHands([]int{2, 5, 3, 5})
+	if err != nil {
+		t.Fatalf("DealHands([2 5 3 5]) got error: %v, want nil", err)
+	}
+	set := HandSet(hands)
+
+	if got, want := set.TotalCards(), 15; got != want {
+		t.Errorf("TotalCards() = %d, want %d", got, want)
+	}
+	if got, want := set.Largest(), hands[1]; !slices.Equal(got, want) {
+		t.Errorf("Largest() = %v, want the first five-card hand %v", got, want)
+	}
+
+	var empty HandSet
+	if got := empty.Largest(); got != nil {
+		t.Errorf("Largest() of an empty set = %v, want nil", got)
+	}
+	if got := empty.TotalCards(); got != 0 {
+		t.Errorf("TotalCards() of an empty set = %d, want 0", got)
+	}
+}
+
+func TestHighestLowestCard(t *testing.T) {
+	hand := []Card{NewCard(Ten, Hearts), NewCard(Ace, Clubs), NewCard(King, Spades), NewCard(Two, Diamonds), NewCard(King, Hearts)}
+	tests := []struct {
+		name        string
+		cards       []Card
+		aceHigh     bool
+		wantHighest Card
+		wantLowest  Card
+	}{
+		{"ace high", hand, true, NewCard(Ace, Clubs), NewCard(Two, Diamonds)},
+		{"ace low", hand, false, NewCard(King, Spades), NewCard(Ace, Clubs)},
+		{"jokers highest", append(slices.Clone(hand), NewBlackJoker(), NewRedJoker()), true, NewBlackJoker(), NewCard(Two, Diamonds)},
+		{"red joker above ace high", []Card{NewRedJoker(), NewCard(Ace, Spades)}, true, NewRedJoker(), NewCard(Ace, Spades)},
+		{"single card", []Card{NewCard(Seven, Clubs)}, true, NewCard(Seven, Clubs), NewCard(Seven, Clubs)},
+		{"ties keep the first card", []Card{NewCard(Five, Clubs), NewCard(Five, Spades)}, true, NewCard(Five, Clubs), NewCard(Five, Clubs)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, ok := HighestCard(tt.cards, tt.aceHigh); !ok || got != tt.wantHighest {
+				t.Errorf("HighestCard(%v, %v) = %v, %v, want %v, true", tt.cards, tt.aceHigh, got, ok, tt.wantHighest)
+			}
+			if got, ok := LowestCard(tt.cards, tt.aceHigh); !ok || got != tt.wantLowest {
+				t.Errorf("LowestCard(%v, %v) = %v, %v, want %v, true", tt.cards, tt.aceHigh, got, ok, tt.wantLowest)
+			}
+		})
+	}
+
+	if got, ok := HighestCard(nil, true); ok || got != Card(0) {
+		t.Errorf("HighestCard(nil) = %v, %v, want zero Card, false", got, ok)
+	}
+	if got, ok := LowestCard(nil, true); ok || got != Card(0) {
+		t.Errorf("LowestCard(nil) = %v, %v, want zero Card, false", got, ok)
+	}
+}
+
+func TestExchangeWithKitty(t *testing.T) {
+	nine, ten, jack := NewCard(Nine, Spades), NewCard(Ten, Spades), NewCard(Jack, Spades)
+	queen, king, ace := NewCard(Queen, Hearts), NewCard(King, Hearts), NewCard(Ace, Hearts)
+
+	hand := []Card{nine, ten, jack, queen}
+	kitty := []Card{king, ace}
+	if err := ExchangeWithKitty(hand, kitty, []Card{ten, nine}); err != nil {
+		t.Fatalf("ExchangeWithKitty() got error: %v, want nil", err)
+	}
+	if want := []Card{jack, queen, king, ace}; !slices.Equal(hand, want) {
+		t.Errorf("After ExchangeWithKitty(), hand = %v, want %v", hand, want)
+	}
+	if want := []Card{ten, nine}; !slices.Equal(kitty, want) {
+		t.Errorf("After ExchangeWithKitty(), kitty = %v, want %v", kitty, want)
+	}
+
+	// Discarding kitty cards keeps the hand, and discard may alias the kitty
+	hand = []Card{nine, ten}
+	kitty = []Card{jack}
+	if err := ExchangeWithKitty(hand, kitty, kitty); err != nil {
+		t.Fatalf("ExchangeWithKitty(hand, kitty, kitty) got error: %v, want nil", err)
+	}
+	if !slices.Equal(hand, []Card{nine, ten}) || !slices.Equal(kitty, []Card{jack}) {
+		t.Errorf("After discarding the kitty, hand = %v, kitty = %v, want unchanged", hand, kitty)
+	}
+
+	// Duplicate cards from multiple decks are matched one copy at a time
+	hand = []Card{nine, nine, ten}
+	kitty = []Card{nine}
+	if err := ExchangeWithKitty(hand, kitty, []Card{nine}); err != nil {
+		t.Fatalf("ExchangeWithKitty() with duplicates got error: %v, want nil", err)
+	}
+	if want := []Card{nine, ten, nine}; !slices.Equal(hand, want) {
+		t.Errorf("After ExchangeWithKitty() with duplicates, hand = %v, want %v", hand, want)
+	}
+}
+
+func TestExchangeWithKittyValidation(t *testing.T) {
+	nine, ten, jack := NewCard(Nine, Spades), NewCard(Ten, Spades), NewCard(Jack, Spades)
+	tests := []struct {
+		name    string
+		discard []Card
+		wantErr string
+	}{
+		{"too few discards", []Card{nine}, "must discard 2 cards to match the kitty, got 1"},
+		{"too many discards", []Card{nine, ten, jack}, "must discard 2 cards to match the kitty, got 3"},
+		{"card not held", []Card{nine, NewCard(Ace, Clubs)}, "discard 1 (Ace of Clubs) is not in the hand or the kitty"},
+		{"card discarded twice", []Card{nine, nine}, "discard 1 (9 of Spades) is not in the hand or the kitty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hand := []Card{nine, ten}
+			kitty := []Card{jack, NewCard(Queen, Spades)}
+			err := ExchangeWithKitty(hand, kitty, tt.discard)
+			if err == nil {
+				t.Fatalf("ExchangeWithKitty(%v) got nil error, want %q", tt.discard, tt.wantErr)
+			}
+			if got, want := err.Error(), tt.wantErr; got != want {
+				t.Errorf("ExchangeWithKitty(%v) error = %q, want %q", tt.discard, got, want)
+			}
+			if !errors.Is(err, ErrInvalidArgument) {
+				t.Errorf("ExchangeWithKitty(%v) error = %v, want ErrInvalidArgument", tt.discard, err)
+			}
+			if !slices.Equal(hand, []Card{nine, ten}) || !slices.Equal(kitty, []Card{jack, NewCard(Queen, Spades)}) {
+				t.Errorf("After failed ExchangeWithKitty(), hand = %v, kitty = %v, want unchanged", hand, kitty)
+			}
+		})
+	}
+}
+
+func TestDrawFromAndPeekFrom(t *testing.T) {
+	first, middle, last := NewCard(Ace, Spades), NewCard(Two, Hearts), NewCard(Three, Clubs)
+	tests := []struct {
+		p        Position
+		want     Card
+		wantRest []Card
+	}{
+		{Top, first, []Card{middle, last}},
+		{Bottom, last, []Card{first, middle}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.p.String(), func(t *testing.T) {
+			d := NewFromCards([]Card{first, middle, last})
+			if got, err := d.PeekFrom(tt.p); err != nil || got != tt.want {
+				t.Errorf("PeekFrom(%v) = %v, %v, want %v, nil", tt.p, got, err, tt.want)
+			}
+			if got := d.Len(); got != 3 {
+				t.Errorf("After PeekFrom(%v), Len() = %d, want 3", tt.p, got)
+			}
+			if got, err := d.DrawFrom(tt.p); err != nil || got != tt.want {
+				t.Errorf("DrawFrom(%v) = %v, %v, want %v, nil", tt.p, got, err, tt.want)
+			}
+			if got := d.Cards(); !slices.Equal(got, tt.wantRest) {
+				t.Errorf("After DrawFrom(%v), Cards() = %v, want %v", tt.p, got, tt.wantRest)
+			}
+		})
+	}
+}
+
+func TestDrawFromAndPeekFromErrors(t *testing.T) {
+	empty := NewFromCards(nil)
+	for _, p := range []Position{Top, Bottom} {
+		if _, err := empty.DrawFrom(p); !errors.Is(err, ErrInsufficientCards) {
+			t.Errorf("DrawFrom(%v) on empty deck error = %v, want ErrInsufficientCards", p, err)
+		}
+		if _, err := empty.PeekFrom(p); !errors.Is(err, ErrInsufficientCards) {
+			t.Errorf("PeekFrom(%v) on empty deck error = %v, want ErrInsufficientCards", p, err)
+		}
+	}
+
+	d := New()
+	invalid := Position(2)
+	if _, err := d.DrawFrom(invalid); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("DrawFrom(%v) error = %v, want ErrInvalidArgument", invalid, err)
+	}
+	if _, err := d.PeekFrom(invalid); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("PeekFrom(%v) error = %v, want ErrInvalidArgument", invalid, err)
+	}
+	if got := d.Len(); got != 52 {
+		t.Errorf("After invalid DrawFrom, Len() = %d, want 52", got)
+	}
+	if got, want := invalid.String(), "Position(2)"; got != want {
+		t.Errorf("Position(2).String() = %q, want %q", got, want)
+	}
+}
+
+func TestSortJokersFirst(t *testing.T) {
+	d := NewWithJokers()
+	d.ShuffleWithSeed(7)
+	d.SortJokersFirst()
+
+	cards := d.Cards()
+	if got, want := cards[:3], []Card{NewRedJoker(), NewBlackJoker(), NewCard(Ace, Spades)}; !slices.Equal(got, want) {
+		t.Errorf("first cards = %v, want %v", got, want)
+	}
+
+	// The rest is in the order produced by Sort
+	sorted := New()
+	sorted.Sort()
+	if got, want := cards[2:], sorted.Cards(); !slices.Equal(got, want) {
+		t.Errorf("cards after the jokers = %v, want %v", got, want)
+	}
+
+	// Without jokers it matches Sort
+	d = New()
+	d.ShuffleWithSeed(7)
+	d.SortJokersFirst()
+	if !d.IsSorted() {
+		t.Errorf("SortJokersFirst() without jokers: IsSorted() = false, want true")
+	}
+}
+
+func TestHasJokers(t *testing.T) {
+	tests := []struct {
+		name string
+		d    *Deck
+		want bool
+	}{
+		{"standard", New(), false},
+		{"with jokers", NewWithJokers(), true},
+		{"empty", NewFromCards(nil), false},
+		{"single joker", NewFromCards([]Card{NewBlackJoker()}), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.d.HasJokers(); got != tt.want {
+				t.Errorf("HasJokers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDealStrict52(t *testing.T) {
+	d := New()
+	hands, err := d.DealStrict52(4, 13)
+	if err != nil {
+		t.Fatalf("DealStrict52(4, 13) got error: %v, want nil", err)
+	}
+	if len(hands) != 4 || len(hands[3]) != 13 || !d.IsEmpty() {
+		t.Errorf("DealStrict52(4, 13) dealt %d hands, deck has %d cards left, want 4 hands and 0 left", len(hands), d.Len())
+	}
+
+	// A joker anywhere in the deck is rejected, even if it would not be dealt
+	d = NewWithJokers()
+	before := d.Cards()
+	_, err = d.DealStrict52(4, 5)
+	if err == nil {
+		t.Fatal("DealStrict52(4, 5) with jokers got nil error, want error")
+	}
+	if got, want := err.Error(), "deck contains 2 jokers, want none"; got != want {
+		t.Errorf("DealStrict52(4, 5) error = %q, want %q", got, want)
+	}
+	if !errors.Is(err, ErrJokersPresent) {
+		t.Errorf("DealStrict52(4, 5) error = %v, want ErrJokersPresent", err)
+	}
+	if got := d.Cards(); !slices.Equal(got, before) {
+		t.Errorf("After failed DealStrict52(), deck = %v, want unchanged", got)
+	}
+
+	single := NewFromCards(append(New().Cards(), NewRedJoker()))
+	if _, err := single.DealStrict52(4, 5); err == nil || err.Error() != "deck contains 1 joker, want none" {
+		t.Errorf("DealStrict52(4, 5) with one joker error = %v, want %q", err, "deck contains 1 joker, want none")
+	}
+
+	// Argument errors take precedence over the joker check
+	if _, err := d.DealStrict52(0, 5); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("DealStrict52(0, 5) error = %v, want ErrInvalidArgument", err)
+	}
+}
+
+func TestCommunityDealer(t *testing.T) {
+	d := New()
+	want := d.Cards()
+	board := NewCommunityDealer(d)
+
+	if _, err := board.Turn(); err == nil || err.Error() != "cannot deal the turn before the flop" {
+		t.Errorf("Turn() before Flop() error = %v, want %q", err, "cannot deal the turn before the flop")
+	}
+	if _, err := board.River(); err == nil || err.Error() != "cannot deal the river before the turn" {
+		t.Errorf("River() before Turn() error = %v, want %q", err, "cannot deal the river before the turn")
+	}
+	if got := d.Len(); got != 52 {
+		t.Fatalf("After out-of-order calls, Len() = %d, want 52", got)
+	}
+
+	flop, err := board.Flop()
+	if err != nil {
+		t.Fatalf("Flop() got error: %v, want nil", err)
+	}
+	if !slices.Equal(flop, want[1:4]) {
+		t.Errorf("Flop() = %v, want %v", flop, want[1:4])
+	}
+	if _, err := board.Flop(); err == nil || err.Error() != "flop has already been dealt" {
+		t.Errorf("second Flop() error = %v, want %q", err, "flop has already been dealt")
+	}
+	if _, err := board.River(); err == nil {
+		t.Error("River() before Turn() got nil error, want error")
+	}
+
+	turn, err := board.Turn()
+	if err != nil || turn != want[5] {
+		t.Errorf("Turn() = %v, %v, want %v, nil", turn, err, want[5])
+	}
+	if _, err := board.Turn(); err == nil || err.Error() != "turn has already been dealt" {
+		t.Errorf("second Turn() error = %v, want %q", err, "turn has already been dealt")
+	}
+
+	river, err := board.River()
+	if err != nil || river != want[7] {
+		t.Errorf("River() = %v, %v, want %v, nil", river, err, want[7])
+	}
+	if _, err := board.River(); err == nil || err.Error() != "river has already been dealt" {
+		t.Errorf("second River() error = %v, want %q", err, "river has already been dealt")
+	}
+
+	if got, wantBoard := board.Board(), []Card{want[1], want[2], want[3], want[5], want[7]}; !slices.Equal(got, wantBoard) {
+		t.Errorf("Board() = %v, want %v", got, wantBoard)
+	}
+	if got := d.Len(); got != 44 {
+		t.Errorf("After the river, Len() = %d, want 44", got)
+	}
+}
+
+func TestCommunityDealerInsufficientCards(t *testing.T) {
+	d := NewFromCards(New().Cards()[:5])
+	board := NewCommunityDealer(d)
+	if _, err := board.Flop(); err != nil {
+		t.Fatalf("Flop() got error: %v, want nil", err)
+	}
+
+	// One card left: the turn needs a burn card as well
+	if _, err := board.Turn(); !errors.Is(err, ErrInsufficientCards) {
+		t.Errorf("Turn() with 1 card error = %v, want ErrInsufficientCards", err)
+	}
+	if got := d.Len(); got != 1 {
+		t.Errorf("After failed Turn(), Len() = %d, want 1", got)
+	}
+	if got := len(board.Board()); got != 3 {
+		t.Errorf("After failed Turn(), len(Board()) = %d, want 3", got)
+	}
+
+	// The turn can still be dealt once the deck has enough cards
+	d.Add(NewCard(King, Clubs))
+	if turn, err := board.Turn(); err != nil || turn != NewCard(King, Clubs) {
+		t.Errorf("Turn() = %v, %v, want %v, nil", turn, err, NewCard(King, Clubs))
+	}
+}
+
+func TestDealRounds(t *testing.T) {
+	tests := []struct {
+		name       string
+		numPlayers int
+		perRound   []int
+	}{
+		{"three then two", 4, []int{3, 2}},
+		{"single round", 3, []int{5}},
+		{"one card per round", 4, []int{1, 1, 1}},
+		{"uneven rounds", 2, []int{2, 3, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := New()
+			d.ShuffleWithSeed(5)
+			src := d.Cards()
+
+			hands, err := d.DealRounds(tt.numPlayers, tt.perRound)
+			if err != nil {
+				t.Fatalf("DealRounds(%d, %v) got error: %v, want nil", tt.numPlayers, tt.perRound, err)
+			}
+
+			// Build the expected hands packet by packet
+			want := make([][]Card, tt.numPlayers)
+			offset := 0
+			for _, packet := range tt.perRound {
+				for p := range want {
+					want[p] = append(want[p], src[offset:offset+packet]...)
+					offset += packet
+				}
+			}
+			for p := range want {
+				if !slices.Equal(hands[p], want[p]) {
+					t.Errorf("DealRounds(%d, %v) hand %d = %v, want %v", tt.numPlayers, tt.perRound, p, hands[p], want[p])
+				}
+			}
+			if got := d.Cards(); !slices.Equal(got, src[offset:]) {
+				t.Errorf("After DealRounds(%d, %v), deck = %v, want %v", tt.numPlayers, tt.perRound, got, src[offset:])
+			}
+		})
+	}
+
+	// Rounds of one card match a round-robin deal, and a single round matches Deal
+	d1, d2 := New(), New()
+	got, _ := d1.DealRounds(4, []int{1, 1, 1})
+	want, _ := d2.DealFrom(0, 4, 3)
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("DealRounds(4, [1 1 1]) = %v, want DealFrom(0, 4, 3) = %v", got, want)
+	}
+	d1, d2 = New(), New()
+	got, _ = d1.DealRounds(4, []int{3})
+	want, _ = d2.Deal(4, 3)
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("DealRounds(4, [3]) = %v, want Deal(4, 3) = %v", got, want)
+	}
+}
+
+func TestDealRoundsValidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		numPlayers int
+		perRound   []int
+		wantErr    string
+		wantKind   error
+	}{
+		{"no rounds", 4, nil, "perRound must contain at least one round", ErrInvalidArgument},
+		{"zero packet", 4, []int{3, 0}, "cards per round must be at least 1, got 0 in round 1", ErrInvalidArgument},
+		{"negative packet", 4, []int{-1}, "cards per round must be at least 1, got -1 in round 0", ErrInvalidArgument},
+		{"zero players", 0, []int{3, 2}, "number of players must be at least 1", ErrInvalidArgument},
+		{"too many cards", 6, []int{5, 5}, "insufficient cards: need 60, have 52", ErrInsufficientCards},
+		{"huge packet", 1, []int{math.MaxInt, math.MaxInt}, "insufficient cards: round 0 needs 9223372036854775807 cards per player, have 52", ErrInsufficientCards},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := New()
+			_, err := d.DealRounds(tt.numPlayers, tt.perRound)
+			if err == nil {
+				t.Fatalf("DealRounds(%d, %v) got nil error, want %q", tt.numPlayers, tt.perRound, tt.wantErr)
+			}
+			if got := err.Error(); got != tt.wantErr {
+				t.Errorf("DealRounds(%d, %v) error = %q, want %q", tt.numPlayers, tt.perRound, got, tt.wantErr)
+			}
+			if !errors.Is(err, tt.wantKind) {
+				t.Errorf("DealRounds(%d, %v) error = %v, want %v", tt.numPlayers, tt.perRound, err, tt.wantKind)
+			}
+			if got := d.Len(); got != 52 {
+				t.Errorf("After failed DealRounds(), Len() = %d, want 52", got)
+			}
+		})
+	}
+}
+
+func TestColorString(t *testing.T) {
+	tests := []struct {
+		card Card
+		want string
+	}{
+		{NewCard(Ace, Spades), "Ace♠"},
+		{NewCard(Ten, Hearts), "\x1b[31m10♥\x1b[0m"},
+		{NewCard(Queen, Diamonds), "\x1b[31mQueen♦\x1b[0m"},
+		{NewCard(Two, Clubs), "2♣"},
+		{NewRedJoker(), "\x1b[31mJKR\x1b[0m"},
+		{NewBlackJoker(), "JKB"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.card.ShortString(), func(t *testing.T) {
+			if got := tt.card.ColorString(true); got != tt.want {
+				t.Errorf("ColorString(true) = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	d := NewFromCards([]Card{NewCard(Ace, Spades), NewCard(Ten, Hearts)})
+	if got, want := d.ColorString(true), "Deck (2 cards): [Ace♠, \x1b[31m10♥\x1b[0m]"; got != want {
+		t.Errorf("Deck.ColorString(true) = %q, want %q", got, want)
+	}
+	if got, want := NewFromCards(nil).ColorString(true), "Empty Deck"; got != want {
+		t.Errorf("empty Deck.ColorString(true) = %q, want %q", got, want)
+	}
+}
+
+func TestColorStringDisabled(t *testing.T) {
+	for _, card := range NewWithJokers().Cards() {
+		if got, want := card.ColorString(false), card.ShortString(); got != want {
+			t.Errorf("ColorString(false) = %q, want %q", got, want)
+		}
+	}
+	d := NewWithJokers()
+	if got, want := d.ColorString(false), d.String(); got != want {
+		t.Errorf("Deck.ColorString(false) = %q, want %q", got, want)
+	}
+}
+
+func TestDealUnique(t *testing.T) {
+	a, b, c := NewCard(Ace, Spades), NewCard(Two, Hearts), NewCard(Three, Clubs)
+	tests := []struct {
+		name       string
+		cards      []Card
+		numPlayers int
+		cardsEach  int
+		want       [][]Card
+		wantRest   []Card
+	}{
+		{
+			name:       "no duplicates in the way",
+			cards:      []Card{a, a, b, b, c},
+			numPlayers: 2, cardsEach: 2,
+			want:     [][]Card{{a, b}, {a, b}},
+			wantRest: []Card{c},
+		},
+		{
+			name:       "duplicate passed to the next player",
+			cards:      []Card{a, c, a, b},
+			numPlayers: 2, cardsEach: 2,
+			want:     [][]Card{{a, b}, {c, a}},
+			wantRest: []Card{},
+		},
+		{
+			name:       "extra copies stay in the deck",
+			cards:      []Card{a, a, a, b, c, b},
+			numPlayers: 2, cardsEach: 2,
+			want:     [][]Card{{a, b}, {a, c}},
+			wantRest: []Card{a, b},
+		},
+		{
+			name:       "fallback groups identical cards",
+			cards:      []Card{a, b, c, a, c, c},
+			numPlayers: 3, cardsEach: 2,
+			want:     [][]Card{{a, c}, {a, c}, {b, c}},
+			wantRest: []Card{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewFromCards(tt.cards)
+			hands, err := d.DealUnique(tt.numPlayers, tt.cardsEach)
+			if err != nil {
+				t.Fatalf("DealUnique(%d, %d) got error: %v, want nil", tt.numPlayers, tt.cardsEach, err)
+			}
+			if !slices.EqualFunc(hands, tt.want, slices.Equal) {
+				t.Errorf("DealUnique(%d, %d) = %v, want %v", tt.numPlayers, tt.cardsEach, hands, tt.want)
+			}
+			if got := d.Cards(); !slices.Equal(got, tt.wantRest) {
+				t.Errorf("After DealUnique(%d, %d), deck = %v, want %v", tt.numPlayers, tt.cardsEach, got, tt.wantRest)
+			}
+		})
+	}
+}
+
+func TestDealUniqueMultiDeck(t *testing.T) {
+	for seed := int64(0); seed < 50; seed++ {
+		d, _ := NewMultiple(3)
+		d.ShuffleWithSeed(seed)
+		before := NewFromCards(d.Cards())
+
+		hands, err := d.DealUnique(3, 52)
+		if err != nil {
+			t.Fatalf("seed %d: DealUnique(3, 52) got error: %v, want nil", seed, err)
+		}
+		for p, hand := range hands {
+			if len(hand) != 52 {
+				t.Errorf("seed %d: hand %d has %d cards, want 52", seed, p, len(hand))
+			}
+			if !NewFromCards(hand).SameMultiset(New()) {
+				t.Errorf("seed %d: hand %d holds duplicate cards", seed, p)
+			}
+		}
+
+		// No cards are lost or created
+		all := NewFromCards(slices.Concat(hands...))
+		if !all.SameMultiset(before) {
+			t.Errorf("seed %d: dealt cards differ from the deck", seed)
+		}
+	}
+}
+
+func TestDealUniqueErrors(t *testing.T) {
+	a, b := NewCard(Ace, Spades), NewCard(Two, Hearts)
+	d := NewFromCards([]Card{a, a, a, b})
+	_, err := d.DealUnique(2, 2)
+	if got, want := fmt.Sprint(err), "insufficient distinct cards: need 4, can deal 3 without duplicates"; got != want {
+		t.Errorf("DealUnique(2, 2) error = %q, want %q", got, want)
+	}
+	if !errors.Is(err, ErrInsufficientCards) {
+		t.Errorf("DealUnique(2, 2) error = %v, want ErrInsufficientCards", err)
+	}
+	if got, want := d.Cards(), []Card{a, a, a, b}; !slices.Equal(got, want) {
+		t.Errorf("After failed DealUnique(), deck = %v, want %v", got, want)
+	}
+
+	if _, err := d.DealUnique(0, 2); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("DealUnique(0, 2) error = %v, want ErrInvalidArgument", err)
+	}
+	if _, err := d.DealUnique(2, 3); !errors.Is(err, ErrInsufficientCards) {
+		t.Errorf("DealUnique(2, 3) error = %v, want ErrInsufficientCards", err)
+	}
+}
+
+func TestRankDistance(t *testing.T) {
+	tests := []struct {
+		a, b    Card
+		aceHigh bool
+		want    int
+	}{
+		{NewCard(King, Spades), NewCard(Ace, Hearts), true, 1},
+		{NewCard(King, Spades), NewCard(Ace, Hearts), false, 12},
+		{NewCard(Ace, Clubs), NewCard(Two, Clubs), false, 1},
+		{NewCard(Ace, Clubs), NewCard(Two, Clubs), true, 12},
+		{NewCard(Five, Diamonds), NewCard(Nine, Spades), false, 4},
+		{NewCard(Nine, Spades), NewCard(Five, Diamonds), true, 4},
+		{NewCard(Seven, Hearts), NewCard(Seven, Clubs), false, 0},
+		{NewCard(Ace, Spades), NewCard(Ace, Hearts), true, 0},
+		{NewRedJoker(), NewCard(Ace, Spades), true, -1},
+		{NewCard(King, Spades), NewBlackJoker(), false, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%+v-%+v-%v", tt.a, tt.b, tt.aceHigh), func(t *testing.T) {
+			if got := RankDistance(tt.a, tt.b, tt.aceHigh); got != tt.want {
+				t.Errorf("RankDistance(%v, %v, %v) = %d, want %d", tt.a, tt.b, tt.aceHigh, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShuffleAndDeal(t *testing.T) {
+	d := New()
+	hands, err := d.ShuffleAndDeal(42, 4, 5)
+	if err != nil {
+		t.Fatalf("ShuffleAndDeal(42, 4, 5) got error: %v, want nil", err)
+	}
+
+	want := New()
+	want.ShuffleWithSeed(42)
+	wantHands, _ := want.Deal(4, 5)
+	if !slices.EqualFunc(hands, wantHands, slices.Equal) {
+		t.Errorf("ShuffleAndDeal(42, 4, 5) = %v, want %v", hands, wantHands)
+	}
+	if got := d.Cards(); !slices.Equal(got, want.Cards()) {
+		t.Errorf("After ShuffleAndDeal(42, 4, 5), deck = %v, want %v", got, want.Cards())
+	}
+
+	// An invalid deal leaves the deck shuffled but undealt
+	d = New()
+	if _, err := d.ShuffleAndDeal(42, 4, 14); !errors.Is(err, ErrInsufficientCards) {
+		t.Errorf("ShuffleAndDeal(42, 4, 14) error = %v, want ErrInsufficientCards", err)
+	}
+	shuffled := New()
+	shuffled.ShuffleWithSeed(42)
+	if got := d.Cards(); !slices.Equal(got, shuffled.Cards()) {
+		t.Errorf("After failed ShuffleAndDeal(), deck = %v, want %v", got, shuffled.Cards())
+	}
+}
@@ -1,7 +1,9 @@
 package deck_test
 
 import (
+	"bytes"
 	"fmt"
+	"math/bits"
 
 	"github.com/pavelnikolov/deck"
 )
@@ -416,3 +418,333 @@ func ExampleDeck_MustDealHands() {
 	// Player 3: 2 cards
 	// Remaining: 44 cards
 }
+
+func ExampleDeck_Cycle() {
+	d := deck.New()
+	d.Cycle()
+
+	top, _ := d.Peek()
+	cards := d.Cards()
+	fmt.Printf("Top card: %s\n", top)
+	fmt.Printf("Bottom card: %s\n", cards[len(cards)-1])
+	// Output:
+	// Top card: 2 of Spades
+	// Bottom card: Ace of Spades
+}
+
+func ExampleNewChaCha8Shuffler() {
+	var seed [32]byte
+	copy(seed[:], "provably-fair game seed 00000001")
+
+	d1 := deck.New()
+	d2 := deck.New()
+	d1.ShuffleWith(deck.NewChaCha8Shuffler(seed))
+	d2.ShuffleWith(deck.NewChaCha8Shuffler(seed))
+
+	c1, _ := d1.Peek()
+	c2, _ := d2.Peek()
+	fmt.Printf("Same top card: %v\n", c1 == c2)
+	// Output:
+	// Same top card: true
+}
+
+func ExampleDeck_DrawChunks() {
+	d := deck.New()
+	rows, err := d.DrawChunks(7)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	fmt.Printf("Rows: %d\n", len(rows))
+	fmt.Printf("Last row: %d cards\n", len(rows[len(rows)-1]))
+	fmt.Printf("Remaining: %d cards\n", d.Len())
+	// Output:
+	// Rows: 8
+	// Last row: 3 cards
+	// Remaining: 0 cards
+}
+
+func ExampleDeck_Checksum() {
+	server := deck.New()
+	server.ShuffleWithSeed(42)
+	data, _ := server.MarshalBinary()
+
+	client := &deck.Deck{}
+	_ = client.UnmarshalBinary(data)
+
+	fmt.Printf("Fingerprints match: %v\n", client.Fingerprint() == server.Fingerprint())
+	fmt.Printf("Checksums match: %v\n", client.Checksum() == server.Checksum())
+	// Output:
+	// Fingerprints match: true
+	// Checksums match: true
+}
+
+func ExampleShuffleCommit() {
+	// In production the seed must be generated with crypto/rand.
+	seed := []byte("secret seed revealed after play!")
+
+	d := deck.New()
+	original := d.Cards()
+
+	// Before the game: shuffle and publish the commitment.
+	commitment := deck.ShuffleCommit(d, seed)
+
+	// After the game: reveal the seed so players can verify.
+	fmt.Printf("Commitment valid: %v\n", deck.VerifyCommitment(commitment, seed))
+	fmt.Printf("Shuffle valid: %v\n", deck.VerifyShuffle(original, seed, d))
+	// Output:
+	// Commitment valid: true
+	// Shuffle valid: true
+}
+
+func ExampleDeck_RemoveCards() {
+	d := deck.New()
+
+	// Strip the known hole cards before simulating opponents.
+	holeCards := []deck.Card{
+		deck.NewCard(deck.Ace, deck.Spades),
+		deck.NewCard(deck.Ace, deck.Hearts),
+	}
+	if err := d.RemoveCards(holeCards); err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	fmt.Printf("Remaining: %d cards\n", d.Len())
+
+	err := d.RemoveCards([]deck.Card{deck.NewCard(deck.Ace, deck.Spades)})
+	fmt.Println("Error:", err)
+	// Output:
+	// Remaining: 50 cards
+	// Error: card not in deck: Ace of Spades
+}
+
+func ExampleDeck_WriteTo() {
+	shoe, _ := deck.NewMultiple(6)
+
+	// Any io.Writer works here, such as a file or a network connection.
+	var buf bytes.Buffer
+	written, err := shoe.WriteTo(&buf)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	received := &deck.Deck{}
+	read, err := received.ReadFrom(&buf)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	fmt.Printf("Wrote %d bytes, read %d bytes\n", written, read)
+	fmt.Printf("Received %d cards\n", received.Len())
+	// Output:
+	// Wrote 316 bytes, read 316 bytes
+	// Received 312 cards
+}
+
+func ExampleDeck_ProbabilityNext() {
+	d := deck.New()
+	isAce := func(c deck.Card) bool { return c.Rank() == deck.Ace }
+
+	fmt.Printf("Next card is an ace: %.3f\n", d.ProbabilityNext(isAce))
+	fmt.Printf("An ace in the next 5 cards: %.3f\n", d.ProbabilityInNext(5, isAce))
+	// Output:
+	// Next card is an ace: 0.077
+	// An ace in the next 5 cards: 0.341
+}
+
+func ExampleDeck_DealWithKitty() {
+	// Euchre uses 24 cards: 9 through Ace of each suit
+	d := deck.New().Filter(func(c deck.Card) bool {
+		return c.Rank() == deck.Ace || c.Rank() >= deck.Nine
+	})
+
+	hands, kitty, err := d.DealWithKitty(4, 5, 4)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	fmt.Printf("Hands: %d of %d cards\n", len(hands), len(hands[0]))
+	fmt.Printf("Kitty: %d cards\n", len(kitty))
+	fmt.Printf("Remaining: %d cards\n", d.Len())
+	// Output:
+	// Hands: 4 of 5 cards
+	// Kitty: 4 cards
+	// Remaining: 0 cards
+}
+
+func ExampleCard_Format() {
+	hand := []deck.Card{
+		deck.NewCard(deck.Ace, deck.Spades),
+		deck.NewCard(deck.Ten, deck.Diamonds),
+		deck.NewCard(deck.Seven, deck.Clubs),
+	}
+
+	for _, card := range hand {
+		fmt.Printf("|%+4v|%-16v|\n", card, card)
+	}
+	// Output:
+	// |Ace♠|Ace of Spades   |
+	// | 10♦|10 of Diamonds  |
+	// |  7♣|7 of Clubs      |
+}
+
+func ExampleDeck_SetupDraw() {
+	d := deck.New()
+
+	// Gin Rummy: 2 players with 10 cards each
+	hands, stock, discardTop, err := d.SetupDraw(2, 10)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	fmt.Printf("Hands: %d of %d cards\n", len(hands), len(hands[0]))
+	fmt.Printf("Discard pile: %s\n", discardTop)
+	fmt.Printf("Stock: %d cards\n", stock.Len())
+	// Output:
+	// Hands: 2 of 10 cards
+	// Discard pile: 8 of Hearts
+	// Stock: 31 cards
+}
+
+func ExampleCardSet() {
+	board := deck.CardSet([]deck.Card{
+		deck.NewCard(deck.Ace, deck.Spades),
+		deck.NewCard(deck.King, deck.Spades),
+		deck.NewCard(deck.Seven, deck.Hearts),
+	})
+	hand := deck.CardSet([]deck.Card{
+		deck.NewCard(deck.Ace, deck.Spades),
+		deck.NewCard(deck.Two, deck.Clubs),
+	})
+
+	fmt.Println("Shared:", deck.DeckFromBitset(board&hand))
+	fmt.Println("Remaining:", deck.New().Len()-bits.OnesCount64(board|hand))
+	// Output:
+	// Shared: Deck (1 cards): [Ace♠]
+	// Remaining: 48
+}
+
+func ExampleDeck_ApplyPermutation() {
+	d := deck.New()
+	perm := d.ShuffleTracked(deck.SecureShuffler{})
+
+	// Replay the logged shuffle on a fresh deck
+	replay := deck.New()
+	if err := replay.ApplyPermutation(perm); err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	fmt.Printf("Replay matches: %v\n", replay.Checksum() == d.Checksum())
+
+	// Undo the shuffle
+	if err := d.ApplyInversePermutation(perm); err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	fmt.Printf("Back in order: %v\n", d.Checksum() == deck.New().Checksum())
+	// Output:
+	// Replay matches: true
+	// Back in order: true
+}
+
+func ExampleShoe() {
+	shoe, err := deck.NewShoe(6)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	// Deal until the cut card comes out
+	for !shoe.NeedsReshuffle() {
+		_, _ = shoe.Draw()
+	}
+	fmt.Printf("Cut card reached after %d of 312 cards\n", shoe.Dealt())
+
+	shoe.Reshuffle()
+	fmt.Printf("After reshuffle: %d cards\n", shoe.Len())
+	// Output:
+	// Cut card reached after 234 of 312 cards
+	// After reshuffle: 312 cards
+}
+
+func ExampleDeck_RemoveRank() {
+	// Build a 32-card Piquet deck by removing 2 through 6
+	d := deck.New()
+	removed := 0
+	for r := deck.Two; r <= deck.Six; r++ {
+		removed += d.RemoveRank(r)
+	}
+
+	fmt.Printf("Removed %d cards, %d remain\n", removed, d.Len())
+	// Output:
+	// Removed 20 cards, 32 remain
+}
+
+func ExampleDeck_BurnAndDraw() {
+	d := deck.New()
+	d.ShuffleWithSeed(42)
+	_, _ = d.Deal(4, 2) // hole cards
+
+	var burned []deck.Card
+	streets := []struct {
+		name  string
+		cards int
+	}{{"Flop", 3}, {"Turn", 1}, {"River", 1}}
+	for _, street := range streets {
+		burn, cards, err := d.BurnAndDraw(1, street.cards)
+		if err != nil {
+			fmt.Println("Error:", err)
+			return
+		}
+		burned = append(burned, burn...)
+		fmt.Printf("%s: %d card(s)\n", street.name, len(cards))
+	}
+	fmt.Printf("Burned: %d cards\n", len(burned))
+	// Output:
+	// Flop: 3 card(s)
+	// Turn: 1 card(s)
+	// River: 1 card(s)
+	// Burned: 3 cards
+}
+
+func ExampleDeck_MarshalText() {
+	d := deck.New()
+	hand, _ := d.DrawN(3)
+	saved := &deck.Deck{}
+	for _, card := range hand {
+		saved.Add(card)
+	}
+	saved.AddJoker(deck.RedJoker)
+
+	text, err := saved.MarshalText()
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	fmt.Println(string(text))
+
+	restored := &deck.Deck{}
+	if err := restored.UnmarshalText(text); err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	fmt.Println(restored)
+	// Output:
+	// Ace♠ 2♠ 3♠ JKR
+	// Deck (4 cards): [Ace♠, 2♠, 3♠, JKR]
+}
+
+func ExampleReduce() {
+	d := deck.New()
+	total := deck.Reduce(d, 0, func(sum int, c deck.Card) int {
+		return sum + min(int(c.Rank()), 10)
+	})
+	fmt.Println("Total value:", total)
+	// Output:
+	// Total value: 340
+}